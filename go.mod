@@ -8,6 +8,7 @@ require (
 	github.com/hajimehoshi/ebiten/v2 v2.8.8
 	github.com/kettek/gobl v0.4.0
 	github.com/kettek/rebui v0.0.0-20250628220114-9877d41832b5
+	github.com/ojrac/opensimplex-go v1.0.2
 	github.com/sytallax/prettylog v0.1.0
 	golang.org/x/image v0.20.0
 )
@@ -22,7 +23,6 @@ require (
 	github.com/jezek/xgb v1.1.1 // indirect
 	github.com/jfreymuth/oggvorbis v1.0.5 // indirect
 	github.com/jfreymuth/vorbis v1.0.2 // indirect
-	github.com/ojrac/opensimplex-go v1.0.2 // indirect
 	github.com/pkg/errors v0.9.1 // indirect
 	github.com/radovskyb/watcher v1.0.7 // indirect
 	golang.org/x/sync v0.8.0 // indirect
@@ -44,6 +44,11 @@ func (c *Cammie) SetPosition(x, y float64) {
 	c.y = y
 }
 
+// Position returns the world coordinates the camera is centered on.
+func (c *Cammie) Position() (float64, float64) {
+	return c.x, c.y
+}
+
 func (c *Cammie) AddPosition(dx, dy float64) {
 	c.x += dx
 	c.y += dy
@@ -11,4 +11,5 @@ func (g *Game) DrawContinent(screen *ebiten.Image, simple bool) {
 		return
 	}
 	g.DrawFiefs(screen, simple)
+	g.DrawSelection(screen)
 }
@@ -74,6 +74,12 @@ func (d *Dialoggies) Update() {
 }
 
 func (d *Dialoggies) Next() {
+	// Each dialog brings its own buttons, so clear out the last one's.
+	for _, node := range d.buttonsNodes {
+		d.layout.RemoveNode(node)
+	}
+	d.buttonsNodes = nil
+
 	if len(d.dialogs) == 0 {
 		return
 	}
@@ -81,81 +87,71 @@ func (d *Dialoggies) Next() {
 	top := d.dialogs[0]
 	//d.dialogs = d.dialogs[1:]
 
-	if len(d.dialogs) == 0 {
-		d.layout.RemoveNode(d.titleNode)
-		d.layout.RemoveNode(d.messageNode)
-		for _, node := range d.buttonsNodes {
-			d.layout.RemoveNode(node)
-		}
-		d.buttonsNodes = nil
-		return
+	if d.titleNode == nil {
+		d.titleNode = d.layout.AddNode(rebui.Node{
+			Type:            "Text",
+			ID:              "title",
+			Width:           "60%",
+			Height:          "30",
+			X:               "50%",
+			Y:               "25%",
+			OriginX:         "-50%",
+			OriginY:         "-50%",
+			ForegroundColor: "white",
+			BackgroundColor: "black",
+			Text:            top.Title,
+			VerticalAlign:   rebui.AlignMiddle,
+			HorizontalAlign: rebui.AlignCenter,
+		})
 	} else {
-		if d.titleNode == nil {
-			d.titleNode = d.layout.AddNode(rebui.Node{
-				Type:            "Text",
-				ID:              "title",
-				Width:           "60%",
-				Height:          "30",
-				X:               "50%",
-				Y:               "25%",
-				OriginX:         "-50%",
-				OriginY:         "-50%",
-				ForegroundColor: "white",
-				BackgroundColor: "black",
-				Text:            top.Title,
-				VerticalAlign:   rebui.AlignMiddle,
-				HorizontalAlign: rebui.AlignCenter,
-			})
-		} else {
-			d.titleNode.Widget.(*widgets.Text).AssignText(top.Title)
-		}
-		top.runningMessage = ""
-		if d.messageNode == nil {
-			d.messageNode = d.layout.AddNode(rebui.Node{
-				Type:            "Text",
-				ID:              "message",
-				X:               "at title",
-				Y:               "after title",
-				Width:           "60%",
-				Height:          "210",
-				ForegroundColor: "white",
-				BackgroundColor: "#00000077",
-				TextWrap:        rebui.WrapWord,
-				Text:            top.runningMessage,
-				/*VerticalAlign:   rebui.AlignMiddle,
-				HorizontalAlign: rebui.AlignCenter,*/
-			})
-		} else {
-			d.messageNode.Widget.(*widgets.Text).AssignText(top.runningMessage)
+		d.titleNode.Widget.(*widgets.Text).AssignText(top.Title)
+	}
+	top.runningMessage = ""
+	if d.messageNode == nil {
+		d.messageNode = d.layout.AddNode(rebui.Node{
+			Type:            "Text",
+			ID:              "message",
+			X:               "at title",
+			Y:               "after title",
+			Width:           "60%",
+			Height:          "210",
+			ForegroundColor: "white",
+			BackgroundColor: "#00000077",
+			TextWrap:        rebui.WrapWord,
+			Text:            top.runningMessage,
+			/*VerticalAlign:   rebui.AlignMiddle,
+			HorizontalAlign: rebui.AlignCenter,*/
+		})
+	} else {
+		d.messageNode.Widget.(*widgets.Text).AssignText(top.runningMessage)
+	}
+	for i, button := range top.Buttons {
+		x := "at message"
+		if i > 0 {
+			x = "after button_" + fmt.Sprintf("%d", i-1)
 		}
-		if d.buttonsNodes == nil {
-			for i, button := range top.Buttons {
-				x := "at message"
-				if i > 0 {
-					x = "after button_" + fmt.Sprintf("%d", i-1)
-				}
-				w := 1.0 / float64(len(top.Buttons))
-				w *= 0.6
-				node := d.layout.AddNode(rebui.Node{
-					Type:            "DialogButton",
-					ID:              "button_" + fmt.Sprintf("%d", i),
-					Width:           fmt.Sprintf("%f%%", w*100),
-					Height:          "30",
-					X:               x,
-					Y:               "after message",
-					ForegroundColor: "white",
-					BackgroundColor: "black",
-					Text:            button,
-					VerticalAlign:   rebui.AlignMiddle,
-					HorizontalAlign: rebui.AlignCenter,
-					FocusIndex:      i + 2, // +2 because title and message take up 1 each
-				})
-				node.Widget.(*DialogButton).OnClick = func() {
-					top.OnSubmit("button_" + fmt.Sprintf("%d", i))
-				}
-			}
+		w := 1.0 / float64(len(top.Buttons))
+		w *= 0.6
+		node := d.layout.AddNode(rebui.Node{
+			Type:            "DialogButton",
+			ID:              "button_" + fmt.Sprintf("%d", i),
+			Width:           fmt.Sprintf("%f%%", w*100),
+			Height:          "30",
+			X:               x,
+			Y:               "after message",
+			ForegroundColor: "white",
+			BackgroundColor: "black",
+			Text:            button,
+			VerticalAlign:   rebui.AlignMiddle,
+			HorizontalAlign: rebui.AlignCenter,
+			FocusIndex:      i + 2, // +2 because title and message take up 1 each
+		})
+		node.Widget.(*DialogButton).OnClick = func() {
+			top.OnSubmit("button_" + fmt.Sprintf("%d", i))
 		}
+		d.buttonsNodes = append(d.buttonsNodes, node)
 	}
+
 }
 
 func (d *Dialoggies) Draw(screen *ebiten.Image) {
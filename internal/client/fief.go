@@ -5,6 +5,7 @@ import (
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
 	"github.com/ketMix/ebijam25/internal/world"
 )
 
@@ -75,6 +76,20 @@ func (g *Game) DrawFiefs(screen *ebiten.Image, simple bool) {
 		}
 	}
 
+	// Tint claimed fiefs with their owner's color.
+	for _, fief := range fiefs {
+		if fief == nil || fief.OwnerID == 0 {
+			continue
+		}
+		tint := g.ownerColor(fief.OwnerID)
+		tint.A = 48
+		vector.DrawFilledRect(screen, float32(fief.X), float32(fief.Y), world.FiefPixelSpan, world.FiefPixelSpan, tint, false)
+	}
+
+	g.DrawResources(screen, simple)
+	g.DrawSettlements(screen, simple)
+	g.DrawItems(screen, simple)
+
 	for _, mob := range g.Continent.Mobs {
 		if mob == nil {
 			g.log.Warn("nil mob found in continent mobs")
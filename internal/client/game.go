@@ -3,10 +3,14 @@ package client
 import (
 	"fmt"
 	"log/slog"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/ketMix/ebijam25/internal/log"
+	"github.com/ketMix/ebijam25/internal/message"
 	"github.com/ketMix/ebijam25/internal/message/event"
 	"github.com/ketMix/ebijam25/internal/message/request"
 	"github.com/ketMix/ebijam25/internal/world"
@@ -22,11 +26,21 @@ type Game struct {
 	continentImage *ebiten.Image
 	fiefImages     []*ebiten.Image
 	cammie         Cammie
+	selection      Selection
 	Debug          bool
 	Dialoggies     Dialoggies
 	Hiscore        Hiscore
+	hud            Hud
 	schlubSystem   map[world.ID]*Schlubs
 	Joined         bool
+	version        int           // Protocol version the server speaks.
+	features       []string      // Features negotiated with the server.
+	rtt            time.Duration // Round-trip time to the server, as last measured by it.
+	spectating     bool          // Whether we joined as a spectator.
+	tableName      string        // Name of the table we're at.
+	tableCode      string        // Join code of the table we're at, if it's private.
+	following      world.ID      // Player whose vision we see while spectating, 0 for everything.
+	techs          Techs
 	//
 	skipTutorial       bool
 	hasSeenFirstMob    bool
@@ -38,6 +52,7 @@ func (g *Game) Setup() {
 	g.log = log.New("game", "client")
 	g.debug.Setup()
 	g.cammie.Setup()
+	g.hud.Setup()
 	g.EventBus = *event.NewBus("client")
 	g.continentImage = ebiten.NewImage(world.ContinentPixelSpan, world.ContinentPixelSpan)
 
@@ -50,7 +65,9 @@ func (g *Game) Setup() {
 				return // Player already exists, no need to add again.
 			}
 		}
-		g.players = append(g.players, world.NewPlayer(evt.Username, evt.ID, evt.Color))
+		player := world.NewPlayer(evt.Username, evt.ID, evt.Color)
+		player.Spectator = evt.Spectator
+		g.players = append(g.players, player)
 	})
 	g.EventBus.Subscribe((event.MetaLeave{}).Type(), func(e event.Event) {
 		evt := e.(*event.MetaLeave)
@@ -59,6 +76,9 @@ func (g *Game) Setup() {
 			if player.ID == evt.ID {
 				g.players = append(g.players[:i], g.players[i+1:]...) // Remove the player from the slice.
 				g.log.Info("player removed", "id", evt.ID, "username", player.Username)
+				if g.following == evt.ID {
+					g.following = 0 // The server already went back to showing us everything.
+				}
 				return
 			}
 		}
@@ -79,12 +99,55 @@ func (g *Game) Setup() {
 			}
 		}
 		if !found {
-			g.players = append(g.players, world.NewPlayer(evt.Username, evt.ID, evt.Color))
+			player := world.NewPlayer(evt.Username, evt.ID, evt.Color)
+			player.Spectator = evt.Spectator
+			g.players = append(g.players, player)
 		}
 
 		g.MobID = evt.MobID
-		g.State.Continent = world.NewContinent(evt.Seed)
+		g.spectating = evt.Spectator
+		g.tableName = evt.TableName
+		g.tableCode = evt.Code
+		g.SetCodec(evt.Codec)
+		g.version = evt.Version
+		g.features = evt.Features
+		if g.HasFeature(message.FeatureResume) {
+			g.SetToken(evt.Token)
+		}
 		g.State.Tickrate = evt.Rate
+
+		// Picking back up where we left off, so keep the world and let the server resend whatever we can see.
+		if evt.Resumed && g.State.Continent != nil && g.State.Continent.Sneed == evt.Seed {
+			g.State.Continent.ClearMobs()
+			g.State.Continent.Items = nil
+			g.State.Continent.ClearResources()
+			g.State.Continent.Settlements = nil
+			g.State.Continent.ClearClaims()
+			clear(g.schlubSystem)
+			g.selection.Set()
+			g.log.Info("session resumed")
+			return
+		}
+
+		g.State.Continent = world.NewContinent(evt.Seed)
+		if g.tableCode != "" {
+			g.Dialoggies.Add(g.tableName, "This table is private, so it won't show up in the lobby.\n\nFriends can join with the code "+g.tableCode+".", []string{"OK"}, func(s string) {
+				g.Dialoggies.dialogs = g.Dialoggies.dialogs[1:] // Remove the dialog from the stack.
+				g.Dialoggies.layout.ClearEvents()
+				g.Dialoggies.Next()
+			})
+		}
+		if g.spectating {
+			g.skipTutorial = true // Nothing to learn when you can't do anything.
+			g.Dialoggies.Add("SCHLUBWORLD", "Welcome to SCHLUBWORLD, "+evt.Username+"!\n\nYou are spectating, so sit back and watch the schlubs fight it out.\n\nMove the camera with WASD and press Tab to follow what each player sees.", []string{"OK"}, func(s string) {
+				g.Dialoggies.dialogs = g.Dialoggies.dialogs[1:] // Remove the dialog from the stack.
+				g.Dialoggies.layout.ClearEvents()
+				g.Dialoggies.Next()
+			})
+			g.Dialoggies.SetTitleColor(evt.Color)
+			PlayAudio("music")
+			return
+		}
 		g.Dialoggies.Add("SCHLUBWORLD", "Welcome to SCHLUBWORLD, "+evt.Username+"!\n\nIn this world, it is up to you to slowly rise to power by converting or defeating other schlubs!\nYour starting character must be kept alive.\n\nYour leader unit, henceforth known as \"you\" is very good at converting other schlubs, but be wary of other players or schlub mobs that are too large!", []string{"Skip Tutorials", "OK"}, func(s string) {
 			if s == "Skip Tutorials" {
 				g.skipTutorial = true
@@ -97,6 +160,21 @@ func (g *Game) Setup() {
 		g.Dialoggies.SetTitleColor(evt.Color) // Just for fanciness.
 		PlayAudio("music")
 	})
+	g.EventBus.Subscribe((event.MetaDisconnect{}).Type(), func(e event.Event) {
+		evt := e.(*event.MetaDisconnect)
+		g.Joined = false
+		g.log.Error("disconnected by server", "reason", evt.Reason)
+		g.Dialoggies.Add("Disconnected", "We got disconnected from the server:\n\n"+evt.Reason, []string{"OK"}, func(s string) {
+			g.Dialoggies.dialogs = g.Dialoggies.dialogs[1:] // Remove the dialog from the stack.
+			g.Dialoggies.layout.ClearEvents()
+			g.Dialoggies.Next()
+		})
+	})
+	g.EventBus.Subscribe((event.MetaPing{}).Type(), func(e event.Event) {
+		evt := e.(*event.MetaPing)
+		g.rtt = time.Duration(evt.RTT) * time.Millisecond
+		g.EventBus.Publish(&request.Pong{Seq: evt.Seq})
+	})
 	g.EventBus.Subscribe((event.MetaRefresh{}).Type(), func(e event.Event) {
 		evt := e.(*event.MetaRefresh)
 		for _, player := range g.players {
@@ -125,7 +203,7 @@ func (g *Game) Setup() {
 
 		g.log.Debug("mob spawned", "id", evt.ID, "owner", evt.Owner, "x", evt.X, "y", evt.Y, "schlubs", len(schlubs))
 		if mob.ID == g.MobID {
-			player := g.Continent.Mobs.FindByID(g.MobID)
+			player := g.Continent.FindMob(g.MobID)
 			if player != nil {
 				g.cammie.SetPosition(player.X, player.Y)
 			}
@@ -161,11 +239,11 @@ func (g *Game) Setup() {
 	})
 	g.EventBus.Subscribe((event.MobDespawn{}).Type(), func(e event.Event) {
 		evt := e.(*event.MobDespawn)
-		if mob := g.Continent.Mobs.FindByID(evt.ID); mob != nil {
+		if mob := g.Continent.FindMob(evt.ID); mob != nil {
 			g.Continent.RemoveMob(mob)
 			// Remove particle system
 			delete(g.schlubSystem, mob.ID)
-			if mob.ID == g.MobID || mob.OwnerID == g.PlayerID {
+			if mob.ID == g.MobID {
 				g.Dialoggies.Add("Death", "A shame you didn't survive!\n\nMake sure to build caravans as needed and change formation!\n\nRestart to hopefully play again.", []string{"OK"}, func(s string) {
 					g.Dialoggies.dialogs = g.Dialoggies.dialogs[1:] // Remove the dialog from the stack.
 					g.Dialoggies.layout.ClearEvents()
@@ -180,24 +258,29 @@ func (g *Game) Setup() {
 	})
 	g.EventBus.Subscribe((event.MobPosition{}).Type(), func(e event.Event) {
 		evt := e.(*event.MobPosition)
-		if mob := g.Continent.Mobs.FindByID(evt.ID); mob != nil {
-			g.Continent.MoveMob(mob, evt.X, evt.Y)
+		if mob := g.Continent.FindMob(evt.ID); mob != nil {
+			// The server has already had its say on where the mob can go.
+			g.Continent.PlaceMob(mob, evt.X, evt.Y)
 			g.log.Debug("mob position updated", "id", evt.ID, "x", evt.X, "y", evt.Y)
 		}
 	})
 	g.EventBus.Subscribe((event.MobMove{}).Type(), func(e event.Event) {
 		evt := e.(*event.MobMove)
-		if mob := g.Continent.Mobs.FindByID(evt.ID); mob != nil {
+		if mob := g.Continent.FindMob(evt.ID); mob != nil {
 			mob.TargetX = evt.X
 			mob.TargetY = evt.Y
 			mob.TargetID = evt.TargetID
+			mob.Path = nil
+			for _, waypoint := range evt.Path {
+				mob.Path = append(mob.Path, world.Waypoint{X: waypoint.X, Y: waypoint.Y})
+			}
 			g.log.Info("mob move requested", "id", evt.ID, "targetX", evt.X, "targetY", evt.Y, "targetID", evt.TargetID)
 		}
 	})
 	g.EventBus.Subscribe((event.MobFormation{}).Type(), func(e event.Event) {
 		evt := e.(*event.MobFormation)
 		// FIXME: We should only check for mobs in the visual radius of the player.
-		if mob := g.Continent.Mobs.FindByID(evt.ID); mob != nil {
+		if mob := g.Continent.FindMob(evt.ID); mob != nil {
 			// I guess find the matching schlubs since we have that as an extra abstraction now.
 			if g.schlubSystem[mob.ID] != nil {
 				g.schlubSystem[mob.ID].Swap(world.SchlubID(evt.OuterKind))
@@ -209,7 +292,7 @@ func (g *Game) Setup() {
 	})
 	g.EventBus.Subscribe((event.MobDamage{}).Type(), func(e event.Event) {
 		evt := e.(*event.MobDamage)
-		if mob := g.Continent.Mobs.FindByID(evt.ID); mob != nil {
+		if mob := g.Continent.FindMob(evt.ID); mob != nil {
 			if len(evt.IDs) > 0 {
 				var schlubs []world.SchlubID
 				for _, id := range evt.IDs {
@@ -236,23 +319,241 @@ func (g *Game) Setup() {
 		for _, id := range evt.IDs {
 			schlubs = append(schlubs, world.SchlubID(id))
 		}
-		if fromMob := g.Continent.Mobs.FindByID(evt.From); fromMob != nil {
-			if toMob := g.Continent.Mobs.FindByID(evt.To); toMob != nil {
-				// Convert schlubs from one mob to another.
-				if g.schlubSystem[fromMob.ID] != nil && g.schlubSystem[toMob.ID] != nil {
-					collected := g.schlubSystem[fromMob.ID].CollectSchlubsByID(schlubs...)
-					g.schlubSystem[toMob.ID].PersuadeSchlubs(collected)
-					g.schlubSystem[fromMob.ID].RemoveSchlubs(schlubs...)
-					g.log.Info("mob converted", "from", evt.From, "to", evt.To, "schlubs", len(evt.IDs))
-				} else {
-					g.log.Warn("mob convert event received but schlub system not found for one or both mobs", "from", evt.From, "to", evt.To)
+		// We might only be able to see one side of the clash.
+		fromMob, toMob := g.Continent.FindMob(evt.From), g.Continent.FindMob(evt.To)
+		if fromMob == nil && toMob == nil {
+			g.log.Warn("mob convert event received but neither mob found", "from", evt.From, "to", evt.To)
+			return
+		}
+		fromSystem, toSystem := g.schlubSystem[evt.From], g.schlubSystem[evt.To]
+		if fromMob != nil && toMob != nil && fromSystem != nil && toSystem != nil {
+			// Convert schlubs from one mob to another.
+			fromMob.RemoveSchlub(schlubs...)
+			toMob.AddSchlub(schlubs...)
+			collected := fromSystem.CollectSchlubsByID(schlubs...)
+			toSystem.PersuadeSchlubs(collected)
+			fromSystem.RemoveSchlubs(schlubs...)
+			g.log.Info("mob converted", "from", evt.From, "to", evt.To, "schlubs", len(evt.IDs))
+			return
+		}
+		if fromMob != nil {
+			fromMob.RemoveSchlub(schlubs...)
+			if fromSystem != nil {
+				fromSystem.RemoveSchlubs(schlubs...)
+			}
+		}
+		if toMob != nil {
+			toMob.AddSchlub(schlubs...)
+			if toSystem != nil {
+				toSystem.AddSchlubs(schlubs...)
+			}
+		}
+		g.log.Info("mob converted out of sight", "from", evt.From, "to", evt.To, "schlubs", len(evt.IDs))
+	})
+	g.EventBus.Subscribe((event.MobAge{}).Type(), func(e event.Event) {
+		evt := e.(*event.MobAge)
+		if len(evt.IDs) != len(evt.Ages) {
+			g.log.Warn("mob age event received with mismatched ages", "id", evt.ID)
+			return
+		}
+		if mob := g.Continent.FindMob(evt.ID); mob != nil {
+			var schlubs []world.SchlubID
+			var ages []int
+			for i, id := range evt.IDs {
+				if evt.Ages[i] < 0 || evt.Ages[i] > world.AgeMax {
+					continue
 				}
-			} else {
-				g.log.Warn("mob convert event received but to mob not found", "to", evt.To)
+				schlubs = append(schlubs, world.SchlubID(id))
+				ages = append(ages, evt.Ages[i])
+				if j := slices.Index(mob.Schlubs, world.SchlubID(id)); j >= 0 {
+					mob.Schlubs[j].SetAgeID(evt.Ages[i])
+				}
+			}
+			if g.schlubSystem[mob.ID] != nil {
+				g.schlubSystem[mob.ID].AgeSchlubs(schlubs, ages)
+			}
+			g.log.Debug("mob aged", "id", evt.ID, "schlubs", len(evt.IDs))
+		} else {
+			g.log.Warn("mob age event received but mob not found", "id", evt.ID)
+		}
+	})
+	g.EventBus.Subscribe((event.MobFood{}).Type(), func(e event.Event) {
+		evt := e.(*event.MobFood)
+		if mob := g.Continent.FindMob(evt.ID); mob != nil {
+			mob.Food = evt.Food
+		}
+	})
+	g.EventBus.Subscribe((event.ResourceSpawn{}).Type(), func(e event.Event) {
+		evt := e.(*event.ResourceSpawn)
+		if evt.Fief < 0 || evt.Fief >= len(g.Continent.Fiefs) {
+			g.log.Warn("resource spawn event received for unknown fief", "id", evt.ID, "fief", evt.Fief)
+			return
+		}
+		if res, _ := g.Continent.FindResource(evt.ID); res != nil {
+			res.Food = evt.Food
+			return
+		}
+		g.Continent.Fiefs[evt.Fief].Resources.Add(&world.Resource{
+			ID:   evt.ID,
+			X:    evt.X,
+			Y:    evt.Y,
+			Food: evt.Food,
+		})
+	})
+	g.EventBus.Subscribe((event.ResourceUpdate{}).Type(), func(e event.Event) {
+		evt := e.(*event.ResourceUpdate)
+		if res, _ := g.Continent.FindResource(evt.ID); res != nil {
+			res.Food = evt.Food
+		}
+	})
+	g.EventBus.Subscribe((event.ResourceDespawn{}).Type(), func(e event.Event) {
+		evt := e.(*event.ResourceDespawn)
+		if res, fief := g.Continent.FindResource(evt.ID); res != nil {
+			fief.Resources.Remove(res)
+		}
+	})
+	g.EventBus.Subscribe((event.FiefClaim{}).Type(), func(e event.Event) {
+		evt := e.(*event.FiefClaim)
+		if evt.Fief < 0 || evt.Fief >= len(g.Continent.Fiefs) {
+			g.log.Warn("fief claim event received for unknown fief", "fief", evt.Fief, "owner", evt.Owner)
+			return
+		}
+		g.Continent.SetFiefOwner(g.Continent.Fiefs[evt.Fief], evt.Owner)
+		g.log.Debug("fief claimed", "fief", evt.Fief, "owner", evt.Owner)
+	})
+	g.EventBus.Subscribe((event.GameWin{}).Type(), func(e event.Event) {
+		evt := e.(*event.GameWin)
+		text := "Someone has claimed enough of the continent to win!"
+		if evt.ID == g.PlayerID {
+			text = "You have claimed enough of the continent to win!\n\nThe schlubs will sing of you for generations."
+		} else {
+			for _, player := range g.players {
+				if player.ID == evt.ID {
+					text = player.Username + " has claimed enough of the continent to win!"
+				}
+			}
+		}
+		g.Dialoggies.Add("Victory", text, []string{"OK"}, func(s string) {
+			g.Dialoggies.dialogs = g.Dialoggies.dialogs[1:] // Remove the dialog from the stack.
+			g.Dialoggies.layout.ClearEvents()
+			g.Dialoggies.Next()
+		})
+	})
+	g.EventBus.Subscribe((event.SettlementSpawn{}).Type(), func(e event.Event) {
+		evt := e.(*event.SettlementSpawn)
+		if g.Continent.Settlements.FindByID(evt.ID) != nil {
+			return
+		}
+		if evt.Fief < 0 || evt.Fief >= len(g.Continent.Fiefs) {
+			g.log.Warn("settlement spawn event received for unknown fief", "id", evt.ID, "fief", evt.Fief)
+			return
+		}
+		g.Continent.Settlements.Add(&world.Settlement{
+			ID:       evt.ID,
+			OwnerID:  evt.Owner,
+			Fief:     evt.Fief,
+			X:        evt.X,
+			Y:        evt.Y,
+			Health:   evt.Health,
+			Failures: evt.Failures,
+		})
+	})
+	g.EventBus.Subscribe((event.SettlementUpdate{}).Type(), func(e event.Event) {
+		evt := e.(*event.SettlementUpdate)
+		if settlement := g.Continent.Settlements.FindByID(evt.ID); settlement != nil {
+			settlement.Health = evt.Health
+			settlement.Failures = evt.Failures
+		}
+	})
+	g.EventBus.Subscribe((event.SettlementDespawn{}).Type(), func(e event.Event) {
+		evt := e.(*event.SettlementDespawn)
+		if settlement := g.Continent.Settlements.FindByID(evt.ID); settlement != nil {
+			g.Continent.Settlements.Remove(settlement)
+		}
+	})
+	g.EventBus.Subscribe((event.ItemSpawn{}).Type(), func(e event.Event) {
+		evt := e.(*event.ItemSpawn)
+		if g.Continent.Items.FindByID(evt.ID) == nil {
+			g.Continent.Items.Add(&world.ItemDrop{
+				ID:   evt.ID,
+				Item: world.Item(evt.Item),
+				X:    evt.X,
+				Y:    evt.Y,
+			})
+		}
+	})
+	g.EventBus.Subscribe((event.ItemDespawn{}).Type(), func(e event.Event) {
+		evt := e.(*event.ItemDespawn)
+		if drop := g.Continent.Items.FindByID(evt.ID); drop != nil {
+			g.Continent.Items.Remove(drop)
+		}
+	})
+	g.EventBus.Subscribe((event.MobPickup{}).Type(), func(e event.Event) {
+		evt := e.(*event.MobPickup)
+		if drop := g.Continent.Items.FindByID(evt.Drop); drop != nil {
+			g.Continent.Items.Remove(drop)
+		}
+		if evt.Item < 0 || evt.Item >= int(world.ItemCount) {
+			g.log.Warn("mob pickup event received with unknown item", "id", evt.ID, "item", evt.Item)
+			return
+		}
+		if mob := g.Continent.FindMob(evt.ID); mob != nil {
+			schlub := world.SchlubID(evt.Schlub)
+			if j := slices.Index(mob.Schlubs, schlub); j >= 0 {
+				mob.Schlubs[j].SetItemID(evt.Item)
 			}
+			if g.schlubSystem[mob.ID] != nil {
+				g.schlubSystem[mob.ID].EquipSchlub(schlub, world.Item(evt.Item))
+			}
+			g.log.Debug("item picked up", "id", evt.ID, "item", world.Item(evt.Item))
 		} else {
-			g.log.Warn("mob convert event received but from mob not found", "from", evt.From)
+			g.log.Warn("mob pickup event received but mob not found", "id", evt.ID)
+		}
+	})
+	g.EventBus.Subscribe((event.MobSplit{}).Type(), func(e event.Event) {
+		evt := e.(*event.MobSplit)
+		fromMob := g.Continent.FindMob(evt.ID)
+		if fromMob == nil {
+			g.log.Warn("mob split event received but mob not found", "id", evt.ID)
+			return
+		}
+		var schlubs []world.SchlubID
+		for _, id := range evt.Schlubs {
+			schlubs = append(schlubs, world.SchlubID(id))
 		}
+		fromMob.RemoveSchlub(schlubs...)
+
+		mob := g.Continent.NewMob(fromMob.OwnerID, evt.To, evt.X, evt.Y)
+		mob.Color = fromMob.Color
+		mob.OuterKind = fromMob.OuterKind
+		mob.AddSchlub(schlubs...)
+		g.schlubSystem[mob.ID] = NewSchlubs(mob)
+		if ps := g.schlubSystem[fromMob.ID]; ps != nil {
+			ps.RemoveSchlubs(schlubs...)
+			g.schlubSystem[mob.ID].Swap(ps.outerSchlubKind)
+		}
+		g.log.Info("mob split", "from", evt.ID, "to", evt.To, "schlubs", len(schlubs))
+	})
+	g.EventBus.Subscribe((event.MobMerge{}).Type(), func(e event.Event) {
+		evt := e.(*event.MobMerge)
+		fromMob := g.Continent.FindMob(evt.From)
+		toMob := g.Continent.FindMob(evt.To)
+		if fromMob == nil || toMob == nil {
+			g.log.Warn("mob merge event received but one or both mobs not found", "from", evt.From, "to", evt.To)
+			return
+		}
+		schlubs := fromMob.Schlubs
+		toMob.AddSchlub(schlubs...)
+		if ps := g.schlubSystem[toMob.ID]; ps != nil {
+			if fromPs := g.schlubSystem[fromMob.ID]; fromPs != nil {
+				ps.PersuadeSchlubs(fromPs.CollectSchlubsByID(schlubs...))
+			} else {
+				ps.AddSchlubs(schlubs...)
+			}
+		}
+		g.Continent.RemoveMob(fromMob)
+		delete(g.schlubSystem, fromMob.ID)
+		g.log.Info("mob merged", "from", evt.From, "to", evt.To, "schlubs", len(schlubs))
 	})
 	g.EventBus.Subscribe((event.MobCreate{}).Type(), func(e event.Event) {
 		evt := e.(*event.MobCreate)
@@ -260,7 +561,7 @@ func (g *Game) Setup() {
 		for _, id := range evt.IDs {
 			schlubs = append(schlubs, world.SchlubID(id))
 		}
-		if mob := g.Continent.Mobs.FindByID(evt.ID); mob != nil {
+		if mob := g.Continent.FindMob(evt.ID); mob != nil {
 			mob.AddSchlub(schlubs...)
 			if ss, ok := g.schlubSystem[evt.ID]; ok {
 				ss.AddSchlubs(schlubs...)
@@ -291,10 +592,23 @@ func (g *Game) Setup() {
 	g.EventBus.Subscribe((request.Formation{}).Type(), func(e event.Event) {
 		g.log.Debug("formation request sent", "event", e)
 	})
+	g.EventBus.Subscribe((request.Split{}).Type(), func(e event.Event) {
+		g.log.Debug("split request sent", "event", e)
+	})
+	g.EventBus.Subscribe((request.Merge{}).Type(), func(e event.Event) {
+		g.log.Debug("merge request sent", "event", e)
+	})
+
+	g.setupTechs()
 
 	g.schlubSystem = make(map[world.ID]*Schlubs)
 }
 
+// HasFeature returns true if the server agreed to the given feature.
+func (g *Game) HasFeature(feature string) bool {
+	return slices.Contains(g.features, feature)
+}
+
 // Update updates the game state and processes events.
 func (g *Game) Update() error {
 	g.Dialoggies.Update()
@@ -304,36 +618,70 @@ func (g *Game) Update() error {
 
 	// Input handling (dialoggies do be blocking, though).
 	if !g.Dialoggies.layout.HasEvents() && len(g.Dialoggies.dialogs) == 0 {
-		// Here is where we'd convert inputs, etc., into requests.
-		// Just for testing.
-		if inpututil.IsMouseButtonJustReleased(ebiten.MouseButtonLeft) {
-			// Convert screen coordinates to world coordinates.
-			x, y := g.cammie.ScreenToWorld(ebiten.CursorPosition())
-			g.EventBus.Publish(&request.Move{
-				X: x,
-				Y: y,
-			})
-			g.log.Debug("move request sent", "x", x, "y", y)
-		}
+		// Here is where we'd convert inputs, etc., into requests. Spectators don't get to give any orders.
+		if g.spectating {
+			g.UpdateSpectating()
+		} else {
+			g.UpdateSelection()
 
-		if inpututil.IsKeyJustPressed(ebiten.KeyF) {
-			// Request a formation change for the player's mob.
-			g.EventBus.Publish(&request.Formation{
-				// Not populating for now...
-			})
-		}
-		if inpututil.IsKeyJustPressed(ebiten.Key1) {
-			g.EventBus.Publish(&request.Construct{
-				Caravan: int(world.SchlubKindCaravanVagrant),
-			})
-		} else if inpututil.IsKeyJustPressed(ebiten.Key2) {
-			g.EventBus.Publish(&request.Construct{
-				Caravan: int(world.SchlubKindCaravanMonk),
-			})
-		} else if inpututil.IsKeyJustPressed(ebiten.Key3) {
-			g.EventBus.Publish(&request.Construct{
-				Caravan: int(world.SchlubKindCaravanWarrior),
-			})
+			if inpututil.IsKeyJustPressed(ebiten.KeyF) {
+				// Request a formation change for the selected mobs.
+				for _, id := range g.SelectedMobIDs() {
+					g.EventBus.Publish(&request.Formation{
+						ID: id,
+					})
+				}
+			}
+			if inpututil.IsKeyJustPressed(ebiten.KeyX) && g.HasFeature(message.FeatureSplit) {
+				// Split off half of each selected mob, leaving the leader behind.
+				for _, id := range g.SelectedMobIDs() {
+					mob := g.Continent.FindMob(id)
+					if mob == nil {
+						continue
+					}
+					var schlubs []int
+					for _, schlub := range mob.Schlubs {
+						if schlub.KindID() != int(world.SchlubKindPlayer) {
+							schlubs = append(schlubs, int(schlub))
+						}
+					}
+					if len(schlubs) >= 2 {
+						g.EventBus.Publish(&request.Split{
+							ID:      mob.ID,
+							Schlubs: schlubs[len(schlubs)/2:],
+						})
+					}
+				}
+			}
+			if inpututil.IsKeyJustPressed(ebiten.KeyM) && g.HasFeature(message.FeatureMerge) {
+				// Call all of our other mobs back to the main one.
+				for _, mob := range g.Continent.OwnedMobs(g.PlayerID) {
+					if mob.ID != g.MobID {
+						g.EventBus.Publish(&request.Merge{
+							From: mob.ID,
+							To:   g.MobID,
+						})
+					}
+				}
+			}
+			g.UpdateTechs()
+			for key, caravan := range map[ebiten.Key]world.SchlubID{
+				ebiten.Key1: world.SchlubKindCaravanVagrant,
+				ebiten.Key2: world.SchlubKindCaravanMonk,
+				ebiten.Key3: world.SchlubKindCaravanWarrior,
+			} {
+				// Only ask for caravans we've unlocked, the server would just say no anyway.
+				if inpututil.IsKeyJustPressed(key) && g.CanConstruct(caravan) {
+					g.EventBus.Publish(&request.Construct{
+						Caravan: int(caravan),
+					})
+				}
+			}
+			if inpututil.IsKeyJustPressed(ebiten.Key4) && g.CanSettle() {
+				g.EventBus.Publish(&request.Construct{
+					Settlement: true,
+				})
+			}
 		}
 
 		// Handle mouse wheel input for zooming.
@@ -354,9 +702,9 @@ func (g *Game) Update() error {
 		if inpututil.IsKeyJustPressed(ebiten.KeySpace) {
 			g.cammie.ToggleLocked()
 			if g.cammie.Locked() {
-				player := g.Continent.Mobs.FindByID(g.MobID)
+				player := g.Continent.FindMob(g.cameraMobID())
 				if player == nil {
-					g.log.Error("camera lock failed: player not found", "mobID", g.MobID)
+					g.log.Error("camera lock failed: player not found", "mobID", g.cameraMobID())
 				} else {
 					// If the camera is locked, center it on the player.
 					g.cammie.SetPosition(player.X, player.Y)
@@ -397,15 +745,20 @@ func (g *Game) Update() error {
 	g.cammie.Update()
 
 	g.Hiscore.Update(g.players)
+	g.hud.Update(g)
 
 	// Update our debug info.
 	if g.Debug {
 		g.UpdateDebug()
 	}
 
-	/*for _, mob := range g.Mobs {
-		mob.Update(&g.State)
-	}*/
+	if g.Continent != nil {
+		for _, mob := range g.Continent.Mobs {
+			// Work stats out the same way the server does, so speed and vision agree with it.
+			mob.RefreshStats(g.Continent.GetContainingFief(mob.X, mob.Y), g.Continent.OwnedFiefs(mob.OwnerID)...)
+			g.PredictMob(mob)
+		}
+	}
 
 	// Update schlubs
 	for _, ps := range g.schlubSystem {
@@ -432,6 +785,11 @@ func (g *Game) UpdateDebug() {
 		sessionString += fmt.Sprintf(" Continent Seed: %d\n", g.State.Continent.Sneed) +
 			fmt.Sprintf(" Continent Size: %d\n", len(g.Continent.Fiefs)) +
 			fmt.Sprintf(" Player ID: %d | Mob ID: %d\n", g.PlayerID, g.MobID) +
+			fmt.Sprintf(" Table: %s | Code: %s\n", g.tableName, g.tableCode) +
+			fmt.Sprintf(" Protocol: v%d | Features: %s\n", g.version, strings.Join(g.features, ", ")) +
+			fmt.Sprintf(" Latency: %dms\n", g.rtt.Milliseconds()) +
+			fmt.Sprintf(" Spectating: %t | Following: %d\n", g.spectating, g.following) +
+			fmt.Sprintf(" Skills: %s\n", strings.Join(g.techs.state.Acquired(), ", ")) +
 			"\n"
 	}
 
@@ -439,12 +797,18 @@ func (g *Game) UpdateDebug() {
 	if g.Continent == nil {
 		playerString += " Continent not initialized\n"
 	} else {
-		if p := g.Continent.Mobs.FindByID(g.MobID); p == nil {
+		if p := g.Continent.FindMob(g.MobID); p == nil {
 			playerString += " Player not found\n"
 		} else {
 			playerString += fmt.Sprintf(" X: %.2f | Y: %.2f\n", p.X, p.Y) +
 				fmt.Sprintf(" Target X: %.2f | Target Y: %.2f\n", p.TargetX, p.TargetY) +
-				"\n"
+				fmt.Sprintf(" Speed: %.2f | Vision: %.0f\n", p.Speed(), p.Vision()) +
+				fmt.Sprintf(" Terrain: %s | Move: %.2f\n", g.Continent.TerrainAt(p.X, p.Y), p.MoveSpeed(g.Continent.TerrainAt(p.X, p.Y))) +
+				fmt.Sprintf(" Food: %d/%d | Upkeep: %d\n", p.Food, p.FoodCapacity(), p.Upkeep())
+			if p.Stats != nil {
+				playerString += fmt.Sprintf(" STR %s | AGI %s | CHA %s | END %s | LCK %s\n", p.Stats.Strength, p.Stats.Agility, p.Stats.Charisma, p.Stats.Endurance, p.Stats.Luck)
+			}
+			playerString += "\n"
 		}
 	}
 
@@ -452,6 +816,12 @@ func (g *Game) UpdateDebug() {
 	worldX, worldY := g.cammie.ScreenToWorld(mX, mY)
 	cursorString := fmt.Sprintf(" Cursor: (%d, %d)\n", mX, mY)
 	cursorString += fmt.Sprintf(" World Coordinates: (%.2f, %.2f)\n", worldX, worldY)
+	if g.Continent != nil {
+		if idx := g.Continent.FiefIndex(g.cammie.Position()); idx >= 0 {
+			fief := g.Continent.Fiefs[idx]
+			cursorString += fmt.Sprintf(" Camera Fief: %s (%d) | %s | Elevation: %.2f | Owner: %d\n", fief.Name, idx, fief.Region(), fief.Elevation, fief.OwnerID)
+		}
+	}
 	g.debug.setLeftText(systemString + sessionString + playerString + cursorString)
 }
 
@@ -467,7 +837,7 @@ func (g *Game) Draw(screen *ebiten.Image) {
 
 	// Center camera on player
 	if g.cammie.Locked() {
-		mob := g.Continent.Mobs.FindByID(g.MobID)
+		mob := g.Continent.FindMob(g.cameraMobID())
 		if mob != nil {
 			g.cammie.SetPosition(mob.X, mob.Y)
 		}
@@ -481,6 +851,7 @@ func (g *Game) Draw(screen *ebiten.Image) {
 	g.cammie.Draw(screen)
 
 	g.Hiscore.Draw(screen)
+	g.hud.Draw(screen)
 
 	// Dialoggies.
 	g.Dialoggies.Draw(screen)
@@ -493,6 +864,7 @@ func (g *Game) Draw(screen *ebiten.Image) {
 
 func (g *Game) Layout(ow, oh int) (int, int) {
 	g.Hiscore.Layout(ow, oh)
+	g.hud.Layout(ow, oh)
 	g.Dialoggies.Layout(float64(ow), float64(oh))
 	// Refresh the camera's image as necessary.
 	g.cammie.Layout(ow, oh)
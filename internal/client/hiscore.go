@@ -2,6 +2,7 @@ package client
 
 import (
 	"fmt"
+	"image/color"
 	"slices"
 
 	"github.com/hajimehoshi/ebiten/v2"
@@ -15,19 +16,41 @@ type Hiscore struct {
 	entries []*rebui.Node
 }
 
+// hiscoreLine is a single line of text in the hiscore list.
+type hiscoreLine struct {
+	text  string
+	color color.NRGBA
+}
+
 func (h *Hiscore) Update(players []*world.Player) {
-	// Clone and sort by player.count.
-	var sortedPlayers []*world.Player
-	sortedPlayers = make([]*world.Player, len(players))
-	copy(sortedPlayers, players)
+	// Clone and sort by player.count, keeping spectators out of the running.
+	var sortedPlayers, spectators []*world.Player
+	for _, player := range players {
+		if player.Spectator {
+			spectators = append(spectators, player)
+		} else {
+			sortedPlayers = append(sortedPlayers, player)
+		}
+	}
 	slices.SortFunc(sortedPlayers, func(a, b *world.Player) int {
 		return b.Count - a.Count
 	})
+	var lines []hiscoreLine
+	for _, player := range sortedPlayers {
+		lines = append(lines, hiscoreLine{player.Username + " - " + fmt.Sprintf("%d", player.Count), player.Color})
+	}
+	// Spectators get listed separately at the bottom.
+	if len(spectators) > 0 {
+		lines = append(lines, hiscoreLine{"Spectating", color.NRGBA{160, 160, 160, 255}})
+		for _, player := range spectators {
+			lines = append(lines, hiscoreLine{player.Username, player.Color})
+		}
+	}
 	// First see if we can reuse existing entries
-	for i, player := range sortedPlayers {
+	for i, line := range lines {
 		if i < len(h.entries) {
-			h.entries[i].Widget.(*widgets.Text).AssignText(player.Username + " - " + fmt.Sprintf("%d", player.Count))
-			h.entries[i].Widget.(*widgets.Text).AssignForegroundColor(player.Color)
+			h.entries[i].Widget.(*widgets.Text).AssignText(line.text)
+			h.entries[i].Widget.(*widgets.Text).AssignForegroundColor(line.color)
 		} else {
 			var y string
 			if i > 0 {
@@ -43,20 +66,21 @@ func (h *Hiscore) Update(players []*world.Player) {
 				X:               "100%",
 				OriginX:         "-100%",
 				Y:               y,
-				Text:            player.Username + " - " + fmt.Sprintf("%d", player.Count),
+				Text:            line.text,
 				VerticalAlign:   rebui.AlignTop,
 				HorizontalAlign: rebui.AlignRight,
 			})
+			entry.Widget.(*widgets.Text).AssignForegroundColor(line.color)
 			entry.Widget.(*widgets.Text).AssignBackgroundColor(nil)
 			entry.Widget.(*widgets.Text).AssignBorderColor(nil)
 			h.entries = append(h.entries, entry)
 		}
 	}
 	// Remove any excess entries
-	for i := len(players); i < len(h.entries); i++ {
+	for i := len(lines); i < len(h.entries); i++ {
 		h.layout.RemoveNode(h.entries[i])
 	}
-	h.entries = h.entries[:len(players)]
+	h.entries = h.entries[:len(lines)]
 }
 
 func (h *Hiscore) Draw(screen *ebiten.Image) {
@@ -0,0 +1,75 @@
+package client
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/ketMix/ebijam25/internal/world"
+	"github.com/kettek/rebui"
+	"github.com/kettek/rebui/widgets"
+)
+
+// Hud shows where the camera is at.
+type Hud struct {
+	layout   rebui.Layout
+	fiefNode *rebui.Node
+	fief     int // Index of the fief being shown, so the label is only rebuilt when it changes.
+	owner    world.ID
+}
+
+func (h *Hud) Setup() {
+	h.fief = -1
+	h.fiefNode = h.layout.AddNode(rebui.Node{
+		Type:            "Text",
+		ID:              "fief",
+		Width:           "40%",
+		Height:          "10%",
+		X:               "50%",
+		OriginX:         "-50%",
+		Y:               "0%",
+		VerticalAlign:   rebui.AlignTop,
+		HorizontalAlign: rebui.AlignCenter,
+	})
+	h.fiefNode.Widget.(*widgets.Text).AssignBackgroundColor(nil)
+	h.fiefNode.Widget.(*widgets.Text).AssignBorderColor(nil)
+}
+
+// Update shows the name of the fief the camera is over, along with its lay of the land and whoever owns it.
+func (h *Hud) Update(g *Game) {
+	if g.Continent == nil {
+		return
+	}
+	idx := g.Continent.FiefIndex(g.cammie.Position())
+	if idx < 0 {
+		h.fief = idx
+		h.fiefNode.Widget.(*widgets.Text).AssignText("")
+		return
+	}
+	fief := g.Continent.Fiefs[idx]
+	if idx == h.fief && fief.OwnerID == h.owner {
+		return
+	}
+	h.fief = idx
+	h.owner = fief.OwnerID
+
+	text := fief.Name + "\n" + fief.Region()
+	textColor := color.NRGBA{255, 255, 255, 255}
+	if fief.OwnerID != 0 {
+		textColor = g.ownerColor(fief.OwnerID)
+		for _, player := range g.players {
+			if player.ID == fief.OwnerID {
+				text += "\nHeld by " + player.Username
+			}
+		}
+	}
+	h.fiefNode.Widget.(*widgets.Text).AssignText(text)
+	h.fiefNode.Widget.(*widgets.Text).AssignForegroundColor(textColor)
+}
+
+func (h *Hud) Draw(screen *ebiten.Image) {
+	h.layout.Draw(screen)
+}
+
+func (h *Hud) Layout(width, height int) {
+	h.layout.Layout(float64(width), float64(height))
+}
@@ -0,0 +1,22 @@
+package client
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/ketMix/ebijam25/internal/world"
+)
+
+// DrawItems draws the item drops lying around.
+func (g *Game) DrawItems(screen *ebiten.Image, simple bool) {
+	for _, drop := range g.Continent.Items {
+		x, y, r := float32(drop.X), float32(drop.Y), float32(world.ItemDropRadius)
+		vector.DrawFilledCircle(screen, x, y, r, drop.Item.Info().Color, true)
+		vector.StrokeCircle(screen, x, y, r+2, 1, color.NRGBA{255, 255, 255, 128}, true)
+		if !simple && g.Debug {
+			ebitenutil.DebugPrintAt(screen, drop.Item.String(), int(x)+int(r)+2, int(y)-8)
+		}
+	}
+}
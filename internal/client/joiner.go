@@ -2,36 +2,74 @@ package client
 
 import (
 	"context"
+	"errors"
+	"sync"
 	"time"
 
 	"github.com/coder/websocket"
 	"github.com/ketMix/ebijam25/internal/message"
 	"github.com/ketMix/ebijam25/internal/message/event"
+	"github.com/ketMix/ebijam25/internal/message/request"
+)
+
+const (
+	reconnectAttempts = 5           // How many times we try to resume before giving up.
+	reconnectBackoff  = time.Second // Delay before the first resume attempt, doubled after each failure.
 )
 
 // Joiner is a badly named struct that handles joining a server.
 type Joiner struct {
-	conn     *websocket.Conn
-	cancel   context.CancelFunc
-	canceled chan bool
+	mu        sync.Mutex
+	conn      *websocket.Conn
+	cancel    context.CancelFunc
+	canceled  chan bool
+	codec     message.Codec // Codec the server told us to use, JSON until then.
+	url       string        // URL we joined, used to reconnect.
+	token     string        // Token the server gave us to resume our session with.
+	heartbeat bool          // Whether the server pings us, in which case a quiet connection is a dead one.
+}
+
+// SetCodec switches the codec used for sending messages.
+func (j *Joiner) SetCodec(name string) {
+	j.codec = message.Negotiate([]string{name})
+}
+
+// SetToken stores the token used to resume the session if the connection drops.
+func (j *Joiner) SetToken(token string) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.token = token
 }
 
-// Send does what you'd expect.
+// Send does what you'd expect. Messages sent while we're between connections are dropped.
 func (j *Joiner) Send(msg message.MessageI) {
-	if j.conn == nil {
-		panic("joiner connection is nil, cannot send message")
+	j.mu.Lock()
+	conn := j.conn
+	j.mu.Unlock()
+	if conn == nil {
+		println("not connected, dropping message:", msg.Type())
+		return
 	}
-	data, err := message.Encode(msg)
+	codec := j.codec
+	if codec == nil {
+		codec = message.JSONCodec
+	}
+	data, err := codec.Encode(msg)
 	if err != nil {
 		panic(err)
 	}
+	kind := websocket.MessageText
+	if codec.Binary() {
+		kind = websocket.MessageBinary
+	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
 	defer cancel()
 
-	err = j.conn.Write(ctx, websocket.MessageText, data)
+	err = conn.Write(ctx, kind, data)
 	if err != nil {
-		panic(err)
+		// The read loop will notice and try to reconnect.
+		println("error sending message:", err.Error())
 	}
 }
 
@@ -41,46 +79,112 @@ func (j *Joiner) Join(secure bool, host string, bus *event.Bus) {
 	defer cancel()
 
 	if secure {
-		host = "wss://" + host
+		j.url = "wss://" + host
 	} else {
-		host = "ws://" + host
+		j.url = "ws://" + host
 	}
 
-	c, _, err := websocket.Dial(ctx, host, nil)
+	c, _, err := websocket.Dial(ctx, j.url, nil)
 	if err != nil {
-		panic(err)
+		bus.Publish(&event.MetaDisconnect{Reason: "could not reach the server"})
+		return
 	}
 
+	j.mu.Lock()
 	j.conn = c
+	j.mu.Unlock()
 
 	go func() {
 		for {
-			ctx, cancel := context.WithTimeout(context.Background(), time.Minute*30)
+			timeout := time.Minute * 30
+			if j.heartbeat {
+				timeout = message.IdleTimeout
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
 			j.cancel = cancel
 
 			kind, data, err := c.Read(ctx)
 			if err != nil {
-				panic(err)
+				// The server turned us away for good, so let the game know why.
+				var closeErr websocket.CloseError
+				if errors.As(err, &closeErr) && closeErr.Code == websocket.StatusPolicyViolation {
+					bus.Publish(&event.MetaDisconnect{Reason: closeErr.Reason})
+					break
+				}
+				// Otherwise it was the network or the server going away, so try to pick up where we left off.
+				println("lost connection:", err.Error())
+				c.Close(websocket.StatusNormalClosure, "bai bai")
+				if c = j.reconnect(); c != nil {
+					continue
+				}
+				reason := "lost connection to the server"
+				if closeErr.Reason != "" {
+					reason = closeErr.Reason
+				}
+				bus.Publish(&event.MetaDisconnect{Reason: reason})
+				j.mu.Lock()
+				j.conn = nil
+				j.mu.Unlock()
+				return
 			}
-			if kind != websocket.MessageText {
+			msg, err := message.CodecFor(kind == websocket.MessageBinary).Decode(data)
+			if errors.Is(err, message.ErrUnknownType) {
+				println("skipping unknown message:", err.Error())
 				continue
-			}
-
-			msg, err := message.Decode(data)
-			if err != nil {
+			} else if err != nil {
 				println("error decoding message:", err.Error())
 				break
 			} else {
+				if _, ok := msg.(*event.MetaPing); ok {
+					j.heartbeat = true
+				}
 				bus.Publish(msg)
 			}
 		}
 
 		c.Close(websocket.StatusNormalClosure, "bai bai")
+		j.mu.Lock()
 		j.conn = nil
+		j.mu.Unlock()
 		j.canceled <- true
 	}()
 }
 
+// reconnect dials the server again and asks to resume our session, backing off between attempts. It returns nil if we have no session to resume or every attempt failed.
+func (j *Joiner) reconnect() *websocket.Conn {
+	j.mu.Lock()
+	j.conn = nil
+	token := j.token
+	j.mu.Unlock()
+	if token == "" {
+		return nil
+	}
+
+	backoff := reconnectBackoff
+	for attempt := 1; attempt <= reconnectAttempts; attempt++ {
+		time.Sleep(backoff)
+		backoff *= 2
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+		c, _, err := websocket.Dial(ctx, j.url, nil)
+		cancel()
+		if err != nil {
+			println("reconnect attempt", attempt, "failed:", err.Error())
+			continue
+		}
+		j.mu.Lock()
+		j.conn = c
+		j.mu.Unlock()
+		// The server replies with a fresh welcome if the token is still good, or closes on us if it isn't.
+		j.Send(&request.Resume{
+			Token:   token,
+			Version: message.ProtocolVersion,
+		})
+		return c
+	}
+	return nil
+}
+
 // Stoppe stoppes.
 func (j *Joiner) Stoppe() {
 	if j.cancel != nil {
@@ -3,6 +3,7 @@ package client
 import (
 	"fmt"
 	"image/color"
+	"slices"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
@@ -30,8 +31,8 @@ func (g *Game) DrawMob(screen *ebiten.Image, mob *world.Mob, simple bool) {
 		ebitenutil.DebugPrintAt(screen, countText, int(mob.X)-10, int(mob.Y)-5)
 	}
 
-	// Draw vision circle for local player
-	if mob.ID == g.MobID {
+	// Draw vision circle for the local player's mobs
+	if mob.OwnerID == g.PlayerID {
 		vision := mob.Vision()
 		vector.StrokeCircle(screen, float32(mob.X), float32(mob.Y), float32(vision), 4, color.NRGBA{0, 0, 255, 64}, false)
 		// Also draw the combat style.
@@ -46,8 +47,49 @@ func (g *Game) DrawMob(screen *ebiten.Image, mob *world.Mob, simple bool) {
 			}
 		}
 		ebitenutil.DebugPrintAt(screen, name, int(mob.X)-10, int(mob.Y)-20)
+		// And how well fed it is.
+		food := fmt.Sprintf("food %d/%d", mob.Food, mob.FoodCapacity())
+		if mob.Food < mob.Upkeep() {
+			food += " starving!"
+		}
+		ebitenutil.DebugPrintAt(screen, food, int(mob.X)-10, int(mob.Y)-35)
+		// And the route it's planning to take.
+		if len(mob.Path) > 0 {
+			x, y := float32(mob.X), float32(mob.Y)
+			for _, waypoint := range mob.Path {
+				vector.StrokeLine(screen, x, y, float32(waypoint.X), float32(waypoint.Y), 2, color.NRGBA{255, 255, 255, 96}, false)
+				x, y = float32(waypoint.X), float32(waypoint.Y)
+				vector.DrawFilledCircle(screen, x, y, 3, color.NRGBA{255, 255, 255, 128}, false)
+			}
+		}
+	}
+
+	// Highlight the mobs we're ordering around.
+	if mob.OwnerID == g.PlayerID && slices.Contains(g.SelectedMobIDs(), mob.ID) {
+		vector.StrokeCircle(screen, float32(mob.X), float32(mob.Y), float32(mob.CombatRadius()+6), 2, color.NRGBA{255, 255, 255, 192}, false)
 	}
 
 	// Also draw a "combat" circle for any mob.
 	vector.StrokeCircle(screen, float32(mob.X), float32(mob.Y), float32(mob.CombatRadius()), 4, color.NRGBA{255, 0, 0, 128}, false)
 }
+
+// PredictMob moves the mob along between server updates the same way the server will, so it doesn't stutter or rubber-band on its way. Whatever the server says next wins.
+func (g *Game) PredictMob(mob *world.Mob) {
+	if g.State.Tickrate == 0 || len(g.Continent.IntersectingMobs(mob)) > 0 {
+		// The server stops mobs that bump into each other, so leave them be until it says otherwise.
+		return
+	}
+	if mob.TargetID != 0 {
+		if target := g.Continent.FindMob(mob.TargetID); target != nil {
+			mob.TargetX, mob.TargetY = target.X, target.Y
+			mob.Path = nil
+		}
+	}
+	// The server moves mobs once a tick, we move them a bit every frame.
+	speed := mob.MoveSpeed(g.Continent.TerrainAt(mob.X, mob.Y)) * float64(g.State.Tickrate) / float64(ebiten.TPS())
+	x, y, moving := mob.Step(speed)
+	if !moving || !g.Continent.CanStep(mob, x, y) {
+		return
+	}
+	g.Continent.PlaceMob(mob, x, y)
+}
@@ -0,0 +1,25 @@
+package client
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+var resourceColor = color.NRGBA{255, 255, 0, 255}
+
+// DrawResources draws the resources we know about as patches that shrink as they're eaten.
+func (g *Game) DrawResources(screen *ebiten.Image, simple bool) {
+	for _, fief := range g.Continent.Fiefs {
+		for _, res := range fief.Resources {
+			size := res.Radius() * 2
+			vector.DrawFilledRect(screen, float32(res.X-size/2), float32(res.Y-size/2), float32(size), float32(size), resourceColor, false)
+			if !simple && g.Debug {
+				ebitenutil.DebugPrintAt(screen, fmt.Sprintf("%d", res.Food), int(res.X-size/2), int(res.Y-size/2)-16)
+			}
+		}
+	}
+}
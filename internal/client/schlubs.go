@@ -6,6 +6,7 @@ import (
 	"math/rand"
 
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
 	"github.com/ketMix/ebijam25/internal/world"
 	"github.com/ketMix/ebijam25/stuff"
 )
@@ -14,6 +15,11 @@ const (
 	SchlubRadius   = 4.0
 	SchlubDiameter = SchlubRadius * 2.0
 
+	// Age looks
+	YoungSchlubScale = 0.7
+	ElderSchlubGrey  = 160
+	CarriedItemSize  = 3.0
+
 	// Physics constants
 	CenterAttraction = 1.0
 	OrbitalForce     = 0.35
@@ -263,6 +269,28 @@ func (s *Schlubs) RemoveSchlubs(schlubs ...world.SchlubID) {
 	s.toRemove = s.toRemove[:0] // Clear the slice for next use
 }
 
+// AgeSchlubs sets the age of each of the given schlubs, changing their IDs to match.
+func (s *Schlubs) AgeSchlubs(schlubs []world.SchlubID, ages []int) {
+	for i, id := range schlubs {
+		for _, p := range s.schlubs {
+			if p.ID == id {
+				p.ID.SetAgeID(ages[i])
+				break
+			}
+		}
+	}
+}
+
+// EquipSchlub gives the schlub an item, changing its ID to match.
+func (s *Schlubs) EquipSchlub(schlub world.SchlubID, item world.Item) {
+	for _, p := range s.schlubs {
+		if p.ID == schlub {
+			p.ID.SetItemID(int(item))
+			return
+		}
+	}
+}
+
 func (s *Schlubs) AddSchlubs(schlub ...world.SchlubID) {
 	for i, id := range schlub {
 		spiralIndex := float64(i)
@@ -477,27 +505,48 @@ func (s *Schlubs) Update(serverRate float64) {
 
 func (s *Schlubs) Draw(screen *ebiten.Image, showNames bool) {
 	for _, p := range s.schlubs {
-		op := &ebiten.DrawImageOptions{}
-		x, y := s.getCartesian(p)
-		op.GeoM.Translate(x, y)
-		color := s.getSchlubColor()
-		op.ColorScale.ScaleWithColor(color)
-
+		var img *ebiten.Image
 		if p.ID.KindID() == int(world.SchlubKindMonk) {
-			screen.DrawImage(s.monkImage, op)
+			img = s.monkImage
 		} else if p.ID.KindID() == int(world.SchlubKindWarrior) {
-			screen.DrawImage(s.warriorImage, op)
+			img = s.warriorImage
 		} else if p.ID.KindID() == int(world.SchlubKindPlayer) {
-			screen.DrawImage(s.playerImage, op)
+			img = s.playerImage
 		} else if p.ID.KindID() == int(world.SchlubKindCaravanMonk) {
-			screen.DrawImage(s.monkCaravanImage, op)
+			img = s.monkCaravanImage
 		} else if p.ID.KindID() == int(world.SchlubKindCaravanWarrior) {
-			screen.DrawImage(s.warriorCaravanImage, op)
+			img = s.warriorCaravanImage
 		} else if p.ID.KindID() == int(world.SchlubKindCaravanVagrant) {
-			screen.DrawImage(s.vagrantCaravanImage, op)
+			img = s.vagrantCaravanImage
 		} else {
-			screen.DrawImage(s.vagrantImage, op)
+			img = s.vagrantImage
+		}
+
+		op := &ebiten.DrawImageOptions{}
+		x, y := s.getCartesian(p)
+		// Young'uns are drawn smaller, shrinking towards their middle.
+		if p.ID.Young() {
+			w, h := float64(img.Bounds().Dx()), float64(img.Bounds().Dy())
+			op.GeoM.Scale(YoungSchlubScale, YoungSchlubScale)
+			op.GeoM.Translate(w*(1-YoungSchlubScale)/2, h*(1-YoungSchlubScale)/2)
 		}
+		op.GeoM.Translate(x, y)
+		color := s.getSchlubColor()
+		// Elders go grey.
+		if p.ID.Elder() {
+			color.R = uint8((int(color.R) + ElderSchlubGrey) / 2)
+			color.G = uint8((int(color.G) + ElderSchlubGrey) / 2)
+			color.B = uint8((int(color.B) + ElderSchlubGrey) / 2)
+		}
+		op.ColorScale.ScaleWithColor(color)
+		screen.DrawImage(img, op)
+
+		// Carried items go in the schlub's hand, off to the side.
+		if item := p.ID.Item(); item != world.ItemNone {
+			w, h := float32(img.Bounds().Dx()), float32(img.Bounds().Dy())
+			vector.DrawFilledRect(screen, float32(x)+w-CarriedItemSize/2, float32(y)+h/2-CarriedItemSize/2, CarriedItemSize, CarriedItemSize, item.Info().Color, false)
+		}
+
 		// For now... we really need to use fate or something to get an offset start.
 		/*if showNames {
 			name := p.GetName() + " " + p.FamilyName()
@@ -0,0 +1,152 @@
+package client
+
+import (
+	"image/color"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/ketMix/ebijam25/internal/message/request"
+	"github.com/ketMix/ebijam25/internal/world"
+)
+
+const selectionDragThreshold = 4 // Pixels the cursor must move before a click becomes a box-select.
+
+// Selection tracks which of the local player's mobs are being ordered around.
+type Selection struct {
+	ids              []world.ID
+	dragging         bool
+	startX, startY   float64 // World position where the current drag started.
+	screenX, screenY int     // Screen position where the current drag started.
+}
+
+// Has returns true if the mob is selected.
+func (s *Selection) Has(id world.ID) bool {
+	return slices.Contains(s.ids, id)
+}
+
+// Set replaces the selection.
+func (s *Selection) Set(ids ...world.ID) {
+	s.ids = append(s.ids[:0], ids...)
+}
+
+// Add adds mobs to the selection.
+func (s *Selection) Add(ids ...world.ID) {
+	for _, id := range ids {
+		if !s.Has(id) {
+			s.ids = append(s.ids, id)
+		}
+	}
+}
+
+// Toggle selects the mob if it isn't selected and deselects it otherwise.
+func (s *Selection) Toggle(id world.ID) {
+	if idx := slices.Index(s.ids, id); idx != -1 {
+		s.ids = append(s.ids[:idx], s.ids[idx+1:]...)
+	} else {
+		s.ids = append(s.ids, id)
+	}
+}
+
+// Prune drops any selected mobs that are no longer in the given mobs.
+func (s *Selection) Prune(owned world.Mobs) {
+	s.ids = slices.DeleteFunc(s.ids, func(id world.ID) bool {
+		return owned.FindByID(id) == nil
+	})
+}
+
+// SelectedMobIDs returns the IDs of the selected mobs, falling back to the player's main mob if nothing is selected.
+func (g *Game) SelectedMobIDs() []world.ID {
+	if len(g.selection.ids) == 0 {
+		return []world.ID{g.MobID}
+	}
+	return g.selection.ids
+}
+
+// UpdateSelection turns clicks and drags into selections and move orders.
+func (g *Game) UpdateSelection() {
+	owned := g.Continent.OwnedMobs(g.PlayerID)
+	g.selection.Prune(owned)
+
+	mX, mY := ebiten.CursorPosition()
+	x, y := g.cammie.ScreenToWorld(mX, mY)
+
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		g.selection.dragging = true
+		g.selection.startX, g.selection.startY = x, y
+		g.selection.screenX, g.selection.screenY = mX, mY
+	}
+
+	if !inpututil.IsMouseButtonJustReleased(ebiten.MouseButtonLeft) {
+		return
+	}
+	additive := ebiten.IsKeyPressed(ebiten.KeyShift)
+
+	if g.selectionBoxActive(mX, mY) {
+		// Box-select every owned mob with its center in the box.
+		minX, maxX := min(g.selection.startX, x), max(g.selection.startX, x)
+		minY, maxY := min(g.selection.startY, y), max(g.selection.startY, y)
+		var ids []world.ID
+		for _, mob := range owned {
+			if mob.X >= minX && mob.X <= maxX && mob.Y >= minY && mob.Y <= maxY {
+				ids = append(ids, mob.ID)
+			}
+		}
+		if additive {
+			g.selection.Add(ids...)
+		} else {
+			g.selection.Set(ids...)
+		}
+		g.log.Debug("box selected mobs", "count", len(ids))
+	} else if mob := mobAt(owned, x, y); mob != nil {
+		if additive {
+			g.selection.Toggle(mob.ID)
+		} else {
+			g.selection.Set(mob.ID)
+		}
+		g.log.Debug("click selected mob", "id", mob.ID)
+	} else {
+		for _, id := range g.SelectedMobIDs() {
+			g.EventBus.Publish(&request.Move{
+				ID: id,
+				X:  x,
+				Y:  y,
+			})
+		}
+		g.log.Debug("move request sent", "x", x, "y", y)
+	}
+	g.selection.dragging = false
+}
+
+// selectionBoxActive returns true if the cursor has been dragged far enough to count as a box-select.
+func (g *Game) selectionBoxActive(mX, mY int) bool {
+	if !g.selection.dragging {
+		return false
+	}
+	dX := mX - g.selection.screenX
+	dY := mY - g.selection.screenY
+	return dX*dX+dY*dY > selectionDragThreshold*selectionDragThreshold
+}
+
+// DrawSelection draws the box-select rectangle, if one is being dragged.
+func (g *Game) DrawSelection(screen *ebiten.Image) {
+	mX, mY := ebiten.CursorPosition()
+	if !g.selectionBoxActive(mX, mY) {
+		return
+	}
+	x, y := g.cammie.ScreenToWorld(mX, mY)
+	minX, maxX := min(g.selection.startX, x), max(g.selection.startX, x)
+	minY, maxY := min(g.selection.startY, y), max(g.selection.startY, y)
+	vector.StrokeRect(screen, float32(minX), float32(minY), float32(maxX-minX), float32(maxY-minY), 2, color.NRGBA{255, 255, 255, 192}, false)
+}
+
+// mobAt returns the first mob whose combat circle contains the given point.
+func mobAt(mobs world.Mobs, x, y float64) *world.Mob {
+	for _, mob := range mobs {
+		if world.CircleIntersectsCircle(mob.X, mob.Y, mob.CombatRadius(), x, y, 0) {
+			return mob
+		}
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package client
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/ketMix/ebijam25/internal/world"
+	"github.com/ketMix/ebijam25/stuff"
+)
+
+// ownerColor returns the color of the given player, or white if we don't know them.
+func (g *Game) ownerColor(owner world.ID) color.NRGBA {
+	for _, player := range g.players {
+		if player.ID == owner {
+			return player.Color
+		}
+	}
+	return color.NRGBA{255, 255, 255, 255}
+}
+
+// DrawSettlements draws the settlements we know about, looking run down once they start going hungry.
+func (g *Game) DrawSettlements(screen *ebiten.Image, simple bool) {
+	for _, settlement := range g.Continent.Settlements {
+		name := "village"
+		if settlement.Struggling() {
+			name = "village-dead"
+		}
+		img := stuff.GetImage(name)
+		if simple || img == nil {
+			r := float32(world.SettlementRadius)
+			vector.DrawFilledRect(screen, float32(settlement.X)-r, float32(settlement.Y)-r, r*2, r*2, g.ownerColor(settlement.OwnerID), false)
+			continue
+		}
+		opts := &ebiten.DrawImageOptions{}
+		opts.GeoM.Translate(-float64(img.Bounds().Dx())/2, -float64(img.Bounds().Dy())/2)
+		opts.GeoM.Translate(settlement.X, settlement.Y)
+		opts.ColorScale.ScaleWithColor(g.ownerColor(settlement.OwnerID))
+		screen.DrawImage(img, opts)
+		if g.Debug {
+			ebitenutil.DebugPrintAt(screen, fmt.Sprintf("%d hp %d hungry", settlement.Health, settlement.Failures), int(settlement.X)-20, int(settlement.Y)+img.Bounds().Dy()/2)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package client
+
+import (
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/ketMix/ebijam25/internal/message"
+	"github.com/ketMix/ebijam25/internal/message/request"
+	"github.com/ketMix/ebijam25/internal/world"
+)
+
+// UpdateSpectating handles the few inputs a spectator has, which is just picking whose vision to follow.
+func (g *Game) UpdateSpectating() {
+	if !inpututil.IsKeyJustPressed(ebiten.KeyTab) || !g.HasFeature(message.FeatureSpectate) {
+		return
+	}
+
+	// Cycle through everyone with a mob, then back around to seeing everything.
+	var ids []world.ID
+	for _, player := range g.players {
+		if !player.Spectator {
+			ids = append(ids, player.ID)
+		}
+	}
+	next := world.ID(0)
+	if i := slices.Index(ids, g.following); i+1 < len(ids) {
+		next = ids[i+1]
+	}
+	g.following = next
+	g.EventBus.Publish(&request.Follow{
+		ID: next,
+	})
+	g.log.Info("following player", "id", next)
+}
+
+// cameraMobID returns the mob the camera locks on to. For spectators this is the biggest mob of whoever they're following.
+func (g *Game) cameraMobID() world.ID {
+	if !g.spectating {
+		return g.MobID
+	}
+	if g.following == 0 {
+		return 0
+	}
+	var biggest *world.Mob
+	for _, mob := range g.Continent.OwnedMobs(g.following) {
+		if biggest == nil || len(mob.Schlubs) > len(biggest.Schlubs) {
+			biggest = mob
+		}
+	}
+	if biggest == nil {
+		return 0
+	}
+	return biggest.ID
+}
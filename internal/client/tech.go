@@ -0,0 +1,112 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/ketMix/ebijam25/internal/message/event"
+	"github.com/ketMix/ebijam25/internal/message/request"
+	"github.com/ketMix/ebijam25/internal/progression"
+	"github.com/ketMix/ebijam25/internal/world"
+)
+
+// Techs is what we know of the tech tree and how far along it we are. The server has the final say, this is just for showing it.
+type Techs struct {
+	tree  *progression.TechTree
+	state progression.TechState
+	used  map[string]time.Time // When each usable skill was last used, for cooldowns.
+}
+
+// setupTechs loads the tech tree and hooks up the tech events.
+func (g *Game) setupTechs() {
+	tree, err := progression.LoadTechTree()
+	if err != nil {
+		g.log.Error("failed to load tech tree", "error", err)
+		tree, _ = progression.ParseTechTree([]byte("{}"))
+	}
+	g.techs.tree = tree
+	g.techs.used = make(map[string]time.Time)
+
+	g.EventBus.Subscribe((event.TechUnlock{}).Type(), func(e event.Event) {
+		evt := e.(*event.TechUnlock)
+		g.techs.state.Add(evt.Skill)
+		g.log.Info("skill unlocked", "tech", evt.Tech, "skill", evt.Skill, "constructs", evt.Constructs)
+	})
+	g.EventBus.Subscribe((event.TechUsed{}).Type(), func(e event.Event) {
+		evt := e.(*event.TechUsed)
+		g.techs.used[evt.Skill] = time.Now()
+	})
+	g.EventBus.Subscribe((request.TechUse{}).Type(), func(e event.Event) {
+		g.log.Debug("tech request sent", "event", e)
+	})
+}
+
+// UpdateTechs opens the tech dialog when asked to.
+func (g *Game) UpdateTechs() {
+	if inpututil.IsKeyJustPressed(ebiten.KeyT) {
+		g.showTechs()
+	}
+}
+
+// CanConstruct returns true if we've unlocked the given caravan.
+func (g *Game) CanConstruct(caravan world.SchlubID) bool {
+	construct, ok := progression.CaravanConstructs[caravan]
+	return ok && g.techs.state.CanConstruct(g.techs.tree, construct)
+}
+
+// CanSettle returns true if we've unlocked settlements.
+func (g *Game) CanSettle() bool {
+	return g.techs.state.CanConstruct(g.techs.tree, progression.ConstructSettlement)
+}
+
+// showTechs lists every skill and what can be done with it, with a button for each skill we can acquire or use right now.
+func (g *Game) showTechs() {
+	var text strings.Builder
+	var buttons []string
+	var skills []string
+	for _, name := range g.techs.tree.Techs() {
+		tech, _ := g.techs.tree.Tech(name)
+		fmt.Fprintf(&text, "%s\n", strings.ToUpper(tech.Name))
+		for _, skill := range tech.Skills {
+			fmt.Fprintf(&text, "  %s - %s ", skill.Name, skill.Description)
+			if _, err := g.techs.state.CanAcquire(g.techs.tree, skill.Name); err == nil {
+				fmt.Fprintf(&text, "(costs %d schlubs)\n", skill.Cost)
+				buttons = append(buttons, "Get "+skill.Name)
+				skills = append(skills, skill.Name)
+				continue
+			} else if errors.Is(err, progression.ErrMissingPrereqs) {
+				fmt.Fprintf(&text, "(needs %s)\n", strings.Join(skill.Prereqs, ", "))
+				continue
+			}
+			if !skill.Usable {
+				text.WriteString("(acquired)\n")
+				continue
+			}
+			cooldown := time.Duration(skill.Cooldown*float64(time.Second)) - time.Since(g.techs.used[skill.Name])
+			if cooldown > 0 {
+				fmt.Fprintf(&text, "(ready in %ds)\n", int(cooldown.Seconds())+1)
+				continue
+			}
+			text.WriteString("(ready)\n")
+			buttons = append(buttons, "Use "+skill.Name)
+			skills = append(skills, skill.Name)
+		}
+	}
+	buttons = append(buttons, "Close")
+
+	g.Dialoggies.Add("Techs", text.String(), buttons, func(s string) {
+		var i int
+		if _, err := fmt.Sscanf(s, "button_%d", &i); err == nil && i < len(skills) {
+			g.EventBus.Publish(&request.TechUse{
+				Tech: skills[i],
+			})
+		}
+		g.Dialoggies.dialogs = g.Dialoggies.dialogs[1:] // Remove the dialog from the stack.
+		g.Dialoggies.layout.ClearEvents()
+		g.Dialoggies.Next()
+	})
+}
@@ -0,0 +1,234 @@
+package game
+
+import (
+	"fmt"
+	"image/color"
+	"math/rand"
+	"strconv"
+	"strings"
+
+	"github.com/ketMix/ebijam25/internal/client"
+	"github.com/ketMix/ebijam25/internal/message"
+	"github.com/ketMix/ebijam25/internal/message/event"
+	"github.com/ketMix/ebijam25/internal/message/request"
+	"github.com/kettek/rebui"
+	"github.com/kettek/rebui/widgets"
+)
+
+const lobbyRefreshTicks = 180 // Ask for a fresh table list every 3 seconds or so while in the lobby.
+
+// lobby is everything we need before we've sat down at a table.
+type lobby struct {
+	name     string
+	color    color.NRGBA
+	table    string // Table ID or join code, as typed in.
+	nodes    []*rebui.Node
+	listNode *rebui.Node
+	ticks    int
+}
+
+// setupLobby adds the lobby's layout and hooks up the lobby events.
+func (g *Game) setupLobby() {
+	g.lobby.listNode = g.layout.AddNode(rebui.Node{
+		Type:            "Text",
+		ID:              "tables",
+		Width:           "50%",
+		Height:          "30%",
+		X:               "50%",
+		Y:               "15%",
+		OriginX:         "-50%",
+		ForegroundColor: "white",
+		VerticalAlign:   rebui.AlignTop,
+		HorizontalAlign: rebui.AlignLeft,
+		Text:            "Looking for tables...",
+	})
+	g.lobby.nodes = append(g.lobby.nodes, g.lobby.listNode)
+
+	node := g.layout.AddNode(rebui.Node{
+		Type:            "TextInput",
+		ID:              "name",
+		Width:           "50%",
+		Height:          "30",
+		X:               "50%",
+		Y:               "50%",
+		OriginX:         "-50%",
+		OriginY:         "-50%",
+		ForegroundColor: "white",
+		BackgroundColor: "black",
+		BorderColor:     "white",
+		VerticalAlign:   rebui.AlignMiddle,
+		HorizontalAlign: rebui.AlignCenter,
+		FocusIndex:      1,
+	})
+	node.Widget.(*widgets.TextInput).OnSubmit = func(s string) {
+		g.lobby.name = s
+		g.joinTable(false)
+	}
+	node.Widget.(*widgets.TextInput).OnChange = func(s string) {
+		g.lobby.name = s
+	}
+	g.lobby.nodes = append(g.lobby.nodes, node)
+
+	colorNode := g.layout.AddNode(rebui.Node{
+		Type:            "TextInput",
+		Width:           "20%",
+		Height:          "30",
+		X:               "after name",
+		Y:               "at name",
+		ForegroundColor: "white",
+		BackgroundColor: "black",
+		BorderColor:     "white",
+		VerticalAlign:   rebui.AlignMiddle,
+		HorizontalAlign: rebui.AlignCenter,
+		FocusIndex:      1,
+	})
+	// Randomize the initial color.
+	clr := &g.lobby.color
+	clr.R = uint8(100 + rand.Intn(155))
+	clr.G = uint8(100 + rand.Intn(155))
+	clr.B = uint8(100 + rand.Intn(155))
+	clr.A = 255
+	colorNode.Widget.(*widgets.TextInput).AssignText("#" + strconv.FormatUint(uint64(clr.R), 16) +
+		strconv.FormatUint(uint64(clr.G), 16) +
+		strconv.FormatUint(uint64(clr.B), 16))
+	colorNode.Widget.(*widgets.TextInput).OnChange = func(s string) {
+		*clr = stringToColor(s, color.NRGBA{255, 255, 255, 255})
+		clr.A = 255 // Ensure alpha is always 255.
+	}
+	g.lobby.nodes = append(g.lobby.nodes, colorNode)
+
+	tableNode := g.layout.AddNode(rebui.Node{
+		Type:            "TextInput",
+		ID:              "table",
+		Width:           "20%",
+		Height:          "30",
+		X:               "at name",
+		Y:               "after name",
+		ForegroundColor: "white",
+		BackgroundColor: "black",
+		BorderColor:     "white",
+		VerticalAlign:   rebui.AlignMiddle,
+		HorizontalAlign: rebui.AlignCenter,
+		FocusIndex:      2,
+	})
+	tableNode.Widget.(*widgets.TextInput).OnChange = func(s string) {
+		g.lobby.table = s
+	}
+	g.lobby.nodes = append(g.lobby.nodes, tableNode)
+
+	// Some buttons for everything that isn't just joining.
+	x := "after table"
+	for i, button := range []struct {
+		text    string
+		onClick func()
+	}{
+		{"Spectate", func() { g.joinTable(true) }},
+		{"Host", func() { g.hostTable(false) }},
+		{"Host Private", func() { g.hostTable(true) }},
+	} {
+		buttonNode := g.layout.AddNode(rebui.Node{
+			Type:            "DialogButton",
+			ID:              fmt.Sprintf("lobby-button-%d", i),
+			Width:           "10%",
+			Height:          "30",
+			X:               x,
+			Y:               "at table",
+			ForegroundColor: "white",
+			BackgroundColor: "black",
+			Text:            button.text,
+			VerticalAlign:   rebui.AlignMiddle,
+			HorizontalAlign: rebui.AlignCenter,
+			FocusIndex:      3 + i,
+		})
+		buttonNode.Widget.(*client.DialogButton).OnClick = button.onClick
+		g.lobby.nodes = append(g.lobby.nodes, buttonNode)
+		x = "after " + buttonNode.ID
+	}
+
+	g.client.EventBus.Subscribe((event.MetaLobby{}).Type(), func(e event.Event) {
+		evt := e.(*event.MetaLobby)
+		text := "Type a table number or join code in the box below, or leave it empty to join any table.\n\n"
+		if evt.Error != "" {
+			text += "Couldn't join: " + evt.Error + "\n\n"
+		}
+		if len(evt.Tables) == 0 {
+			text += "No tables yet, be the first!\n"
+		}
+		for _, table := range evt.Tables {
+			text += fmt.Sprintf("%d. %s - %d/%d players", table.ID, table.Name, table.Players, table.MaxPlayers)
+			if table.Spectators > 0 {
+				text += fmt.Sprintf(", %d watching", table.Spectators)
+			}
+			text += "\n"
+		}
+		g.lobby.listNode.Widget.(*widgets.Text).AssignText(text)
+	})
+	g.client.EventBus.Subscribe((event.MetaTableCreated{}).Type(), func(e event.Event) {
+		evt := e.(*event.MetaTableCreated)
+		// Sit right down at the table we just made.
+		g.client.EventBus.Publish(g.joinRequest(evt.ID, evt.Code, false))
+	})
+	g.client.EventBus.Subscribe((event.MetaWelcome{}).Type(), func(e event.Event) {
+		if g.client.Joined {
+			return // Just a resume.
+		}
+		g.client.Joined = true
+		for _, node := range g.lobby.nodes {
+			g.layout.RemoveNode(node)
+		}
+		g.lobby.nodes = nil
+	})
+
+	g.client.EventBus.Publish(&request.Lobby{})
+}
+
+// updateLobby keeps the table list fresh until we've joined.
+func (g *Game) updateLobby() {
+	if g.client.Joined {
+		return
+	}
+	g.lobby.ticks++
+	if g.lobby.ticks >= lobbyRefreshTicks {
+		g.lobby.ticks = 0
+		g.client.EventBus.Publish(&request.Lobby{})
+	}
+}
+
+// joinTable asks to join whatever table was typed in, or any table if nothing was.
+func (g *Game) joinTable(spectate bool) {
+	var id int
+	var code string
+	if table := strings.TrimSpace(g.lobby.table); table != "" {
+		if n, err := strconv.Atoi(table); err == nil {
+			id = n
+		} else {
+			code = table
+		}
+	}
+	g.client.EventBus.Publish(g.joinRequest(id, code, spectate))
+}
+
+// hostTable asks the server for a new table named after us. We join it once the server says it's ready.
+func (g *Game) hostTable(private bool) {
+	name := "Table"
+	if g.lobby.name != "" {
+		name = g.lobby.name + "'s table"
+	}
+	g.client.EventBus.Publish(&request.CreateTable{
+		Name:    name,
+		Private: private,
+	})
+}
+
+func (g *Game) joinRequest(table int, code string, spectate bool) *request.Join {
+	return &request.Join{
+		Username: g.lobby.name,
+		Color:    g.lobby.color,
+		Codecs:   preferredCodecs,
+		Version:  message.ProtocolVersion,
+		Features: message.Features(),
+		Spectate: spectate,
+		Table:    table,
+		Code:     code,
+	}
+}
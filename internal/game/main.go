@@ -2,24 +2,25 @@ package game
 
 import (
 	"image/color"
-	"math/rand"
-	"strconv"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/ketMix/ebijam25/internal/client"
+	"github.com/ketMix/ebijam25/internal/message"
 	"github.com/ketMix/ebijam25/internal/message/event"
-	"github.com/ketMix/ebijam25/internal/message/request"
 	"github.com/ketMix/ebijam25/internal/server"
 	"github.com/kettek/rebui"
-	"github.com/kettek/rebui/widgets"
 )
 
+// preferredCodecs are the codecs we ask the server for. Swap in []string{message.JSONCodec.Name()} to get readable traffic when debugging.
+var preferredCodecs = message.CodecNames()
+
 type Game struct {
 	Managers  Managers
 	client    client.Game
 	localGame bool
 	garçon    server.Garçon
 	layout    rebui.Layout
+	lobby     lobby
 }
 
 func NewGame(localGame bool) *Game {
@@ -43,65 +44,15 @@ func NewGame(localGame bool) *Game {
 		g.client.Join(true, "schlubs.gamu.group", &g.client.EventBus)
 	}
 
-	// Set up some layout.
-	var clr color.NRGBA
-	var colorNode *rebui.Node
-	node := g.layout.AddNode(rebui.Node{
-		Type:            "TextInput",
-		ID:              "name",
-		Width:           "50%",
-		Height:          "30",
-		X:               "50%",
-		Y:               "50%",
-		OriginX:         "-50%",
-		OriginY:         "-50%",
-		ForegroundColor: "white",
-		BackgroundColor: "black",
-		BorderColor:     "white",
-		VerticalAlign:   rebui.AlignMiddle,
-		HorizontalAlign: rebui.AlignCenter,
-		FocusIndex:      1,
-	})
-	node.Widget.(*widgets.TextInput).OnSubmit = func(s string) {
-		g.client.EventBus.Publish(&request.Join{
-			Username: s,
-			Color:    clr,
-		})
-		g.client.Joined = true
-		g.layout.RemoveNode(node)
-		g.layout.RemoveNode(colorNode)
-	}
-	colorNode = g.layout.AddNode(rebui.Node{
-		Type:            "TextInput",
-		Width:           "20%",
-		Height:          "30",
-		X:               "after name",
-		Y:               "at name",
-		ForegroundColor: "white",
-		BackgroundColor: "black",
-		BorderColor:     "white",
-		VerticalAlign:   rebui.AlignMiddle,
-		HorizontalAlign: rebui.AlignCenter,
-		FocusIndex:      1,
-	})
-	// Randomize the initial color.
-	clr.R = uint8(100 + rand.Intn(155))
-	clr.G = uint8(100 + rand.Intn(155))
-	clr.B = uint8(100 + rand.Intn(155))
-	clr.A = 255
-	colorNode.Widget.(*widgets.TextInput).AssignText("#" + strconv.FormatUint(uint64(clr.R), 16) +
-		strconv.FormatUint(uint64(clr.G), 16) +
-		strconv.FormatUint(uint64(clr.B), 16))
-	colorNode.Widget.(*widgets.TextInput).OnChange = func(s string) {
-		clr = stringToColor(s, color.NRGBA{255, 255, 255, 255})
-		clr.A = 255 // Ensure alpha is always 255.
-	}
+	// Set up the lobby, where we pick a name, a color, and a table.
+	g.setupLobby()
 
 	return g
 }
 
 func (g *Game) Update() error {
 	g.Managers.Update()
+	g.updateLobby()
 	if err := g.client.Update(); err != nil {
 		return err
 	}
@@ -0,0 +1,270 @@
+package message
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"reflect"
+)
+
+// The binary format is a compact alternative to the JSON envelope. A message is written as a uvarint type ID followed by its fields. Every field is prefixed by a uvarint tag holding its field number (declaration order, starting at 1) shifted left by 3 and or'd with its wire type, much like protobuf. Zero-valued fields are left out entirely and unknown fields are skipped, so fields can be appended to a message without breaking older builds.
+//
+// Since field numbers come from declaration order, new fields must only ever be appended to the end of a message. Reordering or removing fields makes older builds read them as the wrong thing. Fields can be bools, numbers, strings, structs, and slices of those. Maps, pointers, arrays, and interfaces aren't supported, and messages with them fail to encode.
+const (
+	wireVarint  = 0 // bools, ints (zigzag), and uints
+	wireFixed64 = 1 // float64
+	wireBytes   = 2 // strings, slices, and structs, prefixed by their byte length
+	wireFixed32 = 5 // float32
+)
+
+// ErrMalformed is returned by DecodeBinary when the data cannot be read.
+var ErrMalformed = errors.New("malformed binary message")
+
+// binaryTypes maps binary type IDs to their registered message type.
+var binaryTypes = map[uint64]string{}
+
+// typeID hashes a message type into the ID used by the binary format. Hashing keeps IDs stable regardless of registration order.
+func typeID(messageType string) uint64 {
+	h := fnv.New32a()
+	h.Write([]byte(messageType))
+	return uint64(h.Sum32())
+}
+
+// EncodeBinary takes a MessageI instance and returns a byte slice containing the binary-encoded message.
+func EncodeBinary(message MessageI) ([]byte, error) {
+	v := reflect.ValueOf(message)
+	if v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("cannot binary encode %T: not a struct", message)
+	}
+	data := binary.AppendUvarint(nil, typeID(message.Type()))
+	return appendFields(data, v)
+}
+
+// DecodeBinary takes a byte slice containing a binary-encoded message and returns a newly allocated MessageI instance of the corresponding type. It returns ErrUnknownType if the type has not been registered.
+func DecodeBinary(data []byte) (MessageI, error) {
+	id, n := binary.Uvarint(data)
+	if n <= 0 {
+		return nil, ErrMalformed
+	}
+	messageType, exists := binaryTypes[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: binary id %d", ErrUnknownType, id)
+	}
+
+	message := registry[messageType]()
+	if err := readFields(data[n:], reflect.ValueOf(message).Elem()); err != nil {
+		return nil, err
+	}
+	return message, nil
+}
+
+// wireType returns the wire type used for values of the given kind.
+func wireType(kind reflect.Kind) (uint64, bool) {
+	switch kind {
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return wireVarint, true
+	case reflect.Float64:
+		return wireFixed64, true
+	case reflect.Float32:
+		return wireFixed32, true
+	case reflect.String, reflect.Slice, reflect.Struct:
+		return wireBytes, true
+	}
+	return 0, false
+}
+
+func appendFields(data []byte, v reflect.Value) ([]byte, error) {
+	t := v.Type()
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		fv := v.Field(i)
+		if fv.IsZero() {
+			continue
+		}
+		wire, ok := wireType(fv.Kind())
+		if !ok {
+			return nil, fmt.Errorf("cannot binary encode %s.%s of kind %s", t.Name(), field.Name, fv.Kind())
+		}
+		data = binary.AppendUvarint(data, uint64(i+1)<<3|wire)
+		var err error
+		if data, err = appendValue(data, fv); err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+func appendValue(data []byte, v reflect.Value) ([]byte, error) {
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			return binary.AppendUvarint(data, 1), nil
+		}
+		return binary.AppendUvarint(data, 0), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return binary.AppendVarint(data, v.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return binary.AppendUvarint(data, v.Uint()), nil
+	case reflect.Float64:
+		return binary.LittleEndian.AppendUint64(data, math.Float64bits(v.Float())), nil
+	case reflect.Float32:
+		return binary.LittleEndian.AppendUint32(data, math.Float32bits(float32(v.Float()))), nil
+	case reflect.String:
+		data = binary.AppendUvarint(data, uint64(v.Len()))
+		return append(data, v.String()...), nil
+	case reflect.Slice:
+		var payload []byte
+		for i := range v.Len() {
+			var err error
+			if payload, err = appendValue(payload, v.Index(i)); err != nil {
+				return nil, err
+			}
+		}
+		data = binary.AppendUvarint(data, uint64(len(payload)))
+		return append(data, payload...), nil
+	case reflect.Struct:
+		payload, err := appendFields(nil, v)
+		if err != nil {
+			return nil, err
+		}
+		data = binary.AppendUvarint(data, uint64(len(payload)))
+		return append(data, payload...), nil
+	}
+	return nil, fmt.Errorf("cannot binary encode value of kind %s", v.Kind())
+}
+
+func readFields(data []byte, v reflect.Value) error {
+	t := v.Type()
+	for len(data) > 0 {
+		tag, n := binary.Uvarint(data)
+		if n <= 0 {
+			return ErrMalformed
+		}
+		data = data[n:]
+		num := int(tag >> 3)
+		wire := tag & 7
+
+		// Skip anything we don't know about or that changed type under us.
+		var fv reflect.Value
+		if num >= 1 && num <= t.NumField() && t.Field(num-1).IsExported() {
+			if expected, ok := wireType(t.Field(num - 1).Type.Kind()); ok && expected == wire {
+				fv = v.Field(num - 1)
+			}
+		}
+
+		var err error
+		if fv.IsValid() {
+			data, err = readValue(data, fv)
+		} else {
+			data, err = skipValue(data, wire)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readValue(data []byte, v reflect.Value) ([]byte, error) {
+	switch v.Kind() {
+	case reflect.Bool:
+		x, n := binary.Uvarint(data)
+		if n <= 0 {
+			return nil, ErrMalformed
+		}
+		v.SetBool(x != 0)
+		return data[n:], nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		x, n := binary.Varint(data)
+		if n <= 0 || v.OverflowInt(x) {
+			return nil, ErrMalformed
+		}
+		v.SetInt(x)
+		return data[n:], nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		x, n := binary.Uvarint(data)
+		if n <= 0 || v.OverflowUint(x) {
+			return nil, ErrMalformed
+		}
+		v.SetUint(x)
+		return data[n:], nil
+	case reflect.Float64:
+		if len(data) < 8 {
+			return nil, ErrMalformed
+		}
+		v.SetFloat(math.Float64frombits(binary.LittleEndian.Uint64(data)))
+		return data[8:], nil
+	case reflect.Float32:
+		if len(data) < 4 {
+			return nil, ErrMalformed
+		}
+		v.SetFloat(float64(math.Float32frombits(binary.LittleEndian.Uint32(data))))
+		return data[4:], nil
+	case reflect.String, reflect.Slice, reflect.Struct:
+		payload, rest, err := readBytes(data)
+		if err != nil {
+			return nil, err
+		}
+		switch v.Kind() {
+		case reflect.String:
+			v.SetString(string(payload))
+		case reflect.Slice:
+			slice := reflect.MakeSlice(v.Type(), 0, 0)
+			for len(payload) > 0 {
+				elem := reflect.New(v.Type().Elem()).Elem()
+				if payload, err = readValue(payload, elem); err != nil {
+					return nil, err
+				}
+				slice = reflect.Append(slice, elem)
+			}
+			v.Set(slice)
+		case reflect.Struct:
+			if err := readFields(payload, v); err != nil {
+				return nil, err
+			}
+		}
+		return rest, nil
+	}
+	return nil, fmt.Errorf("cannot binary decode value of kind %s", v.Kind())
+}
+
+// readBytes reads a length-prefixed payload, returning it along with the remaining data.
+func readBytes(data []byte) ([]byte, []byte, error) {
+	length, n := binary.Uvarint(data)
+	if n <= 0 || uint64(len(data)-n) < length {
+		return nil, nil, ErrMalformed
+	}
+	end := n + int(length)
+	return data[n:end], data[end:], nil
+}
+
+func skipValue(data []byte, wire uint64) ([]byte, error) {
+	switch wire {
+	case wireVarint:
+		if _, n := binary.Uvarint(data); n > 0 {
+			return data[n:], nil
+		}
+	case wireFixed64:
+		if len(data) >= 8 {
+			return data[8:], nil
+		}
+	case wireFixed32:
+		if len(data) >= 4 {
+			return data[4:], nil
+		}
+	case wireBytes:
+		_, rest, err := readBytes(data)
+		return rest, err
+	}
+	return nil, ErrMalformed
+}
@@ -0,0 +1,62 @@
+package message
+
+// Codec encodes and decodes messages for sending over the wire.
+type Codec interface {
+	Name() string
+	Binary() bool // Binary reports whether encoded messages are binary rather than text.
+	Encode(message MessageI) ([]byte, error)
+	Decode(data []byte) (MessageI, error)
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Name() string                            { return "json" }
+func (jsonCodec) Binary() bool                            { return false }
+func (jsonCodec) Encode(message MessageI) ([]byte, error) { return Encode(message) }
+func (jsonCodec) Decode(data []byte) (MessageI, error)    { return Decode(data) }
+
+type binaryCodec struct{}
+
+func (binaryCodec) Name() string                            { return "binary" }
+func (binaryCodec) Binary() bool                            { return true }
+func (binaryCodec) Encode(message MessageI) ([]byte, error) { return EncodeBinary(message) }
+func (binaryCodec) Decode(data []byte) (MessageI, error)    { return DecodeBinary(data) }
+
+var (
+	// JSONCodec is the human-readable TypedMessage envelope. It is handy for debugging and is what everything falls back to.
+	JSONCodec Codec = jsonCodec{}
+	// BinaryCodec is the compact varint-tagged format.
+	BinaryCodec Codec = binaryCodec{}
+)
+
+// codecs are all supported codecs, most compact first.
+var codecs = []Codec{BinaryCodec, JSONCodec}
+
+// CodecNames returns the names of all supported codecs, most compact first.
+func CodecNames() []string {
+	var names []string
+	for _, codec := range codecs {
+		names = append(names, codec.Name())
+	}
+	return names
+}
+
+// CodecFor returns the codec that handles binary or text data.
+func CodecFor(binary bool) Codec {
+	if binary {
+		return BinaryCodec
+	}
+	return JSONCodec
+}
+
+// Negotiate returns the first of the requested codecs that is supported. It falls back to JSON, so clients that don't ask for anything keep working.
+func Negotiate(names []string) Codec {
+	for _, name := range names {
+		for _, codec := range codecs {
+			if codec.Name() == name {
+				return codec
+			}
+		}
+	}
+	return JSONCodec
+}
@@ -0,0 +1,139 @@
+package message_test
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/ketMix/ebijam25/internal/message"
+	_ "github.com/ketMix/ebijam25/internal/message/event"
+	_ "github.com/ketMix/ebijam25/internal/message/request"
+)
+
+// populate fills every exported field with something other than its zero value, so nothing gets left out of encoding. Numbers come from counter so fields can't stand in for each other.
+func populate(v reflect.Value, counter *int) {
+	*counter++
+	n := *counter
+	switch v.Kind() {
+	case reflect.Bool:
+		v.SetBool(true)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		// Negatives too, to exercise zigzag.
+		if n%2 == 0 {
+			n = -n
+		}
+		v.SetInt(int64(n))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v.SetUint(uint64(n))
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(float64(n) + 0.5)
+	case reflect.String:
+		v.SetString(fmt.Sprintf("value %d", n))
+	case reflect.Slice:
+		slice := reflect.MakeSlice(v.Type(), 2, 2)
+		for i := range slice.Len() {
+			populate(slice.Index(i), counter)
+		}
+		v.Set(slice)
+	case reflect.Array:
+		for i := range v.Len() {
+			populate(v.Index(i), counter)
+		}
+	case reflect.Map:
+		key := reflect.New(v.Type().Key()).Elem()
+		elem := reflect.New(v.Type().Elem()).Elem()
+		populate(key, counter)
+		populate(elem, counter)
+		v.Set(reflect.MakeMap(v.Type()))
+		v.SetMapIndex(key, elem)
+	case reflect.Pointer:
+		v.Set(reflect.New(v.Type().Elem()))
+		populate(v.Elem(), counter)
+	case reflect.Struct:
+		for i := range v.NumField() {
+			if v.Type().Field(i).IsExported() {
+				populate(v.Field(i), counter)
+			}
+		}
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	types := message.RegisteredTypes()
+	slices.Sort(types)
+	if len(types) == 0 {
+		t.Fatal("no message types registered")
+	}
+	for _, codec := range []message.Codec{message.JSONCodec, message.BinaryCodec} {
+		for _, messageType := range types {
+			t.Run(codec.Name()+"/"+messageType, func(t *testing.T) {
+				for _, filled := range []bool{false, true} {
+					original := message.NewRegistered(messageType)
+					if filled {
+						counter := 0
+						populate(reflect.ValueOf(original).Elem(), &counter)
+					}
+					data, err := codec.Encode(original)
+					if err != nil {
+						t.Fatalf("encode %+v: %v", original, err)
+					}
+					decoded, err := codec.Decode(data)
+					if err != nil {
+						t.Fatalf("decode %+v: %v", original, err)
+					}
+					if !reflect.DeepEqual(normalize(original), normalize(decoded)) {
+						t.Errorf("got %+v, want %+v", decoded, original)
+					}
+				}
+			})
+		}
+	}
+}
+
+// normalize treats empty and nil slices as the same, since neither codec keeps the difference.
+func normalize(msg message.MessageI) any {
+	v := reflect.New(reflect.TypeOf(msg).Elem()).Elem()
+	v.Set(reflect.ValueOf(msg).Elem())
+	normalizeValue(v)
+	return v.Interface()
+}
+
+func normalizeValue(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Slice:
+		if v.Len() == 0 {
+			v.Set(reflect.Zero(v.Type()))
+			return
+		}
+		for i := range v.Len() {
+			normalizeValue(v.Index(i))
+		}
+	case reflect.Struct:
+		for i := range v.NumField() {
+			if v.Type().Field(i).IsExported() {
+				normalizeValue(v.Field(i))
+			}
+		}
+	}
+}
+
+func TestBinaryRejectsUnsupported(t *testing.T) {
+	for _, msg := range []message.MessageI{
+		&unsupported{Array: [2]int{1, 2}},
+		&unsupported{Map: map[string]int{"a": 1}},
+		&unsupported{Pointer: new(int)},
+	} {
+		if _, err := message.BinaryCodec.Encode(msg); err == nil {
+			t.Errorf("encoded %+v", msg)
+		}
+	}
+}
+
+type unsupported struct {
+	Array   [2]int
+	Map     map[string]int
+	Pointer *int
+}
+
+func (unsupported) Type() string { return "unsupported" }
@@ -99,6 +99,12 @@ func (b *Bus) ProcessEvents() {
 	b.nextEvents = nil
 }
 
+// Discard drops any queued events without processing them.
+func (b *Bus) Discard() {
+	b.events = nil
+	b.nextEvents = nil
+}
+
 func (b *Bus) Pipe(other *Bus, events []string) {
 	for _, eventType := range events {
 		if b.eventToPipe == nil {
@@ -0,0 +1,20 @@
+package event
+
+import (
+	"github.com/ketMix/ebijam25/internal/message"
+)
+
+// FiefClaim represents a fief changing hands.
+type FiefClaim struct {
+	Fief  int `json:"fief"`  // Index of the fief
+	Owner int `json:"owner"` // ID of the player who now owns it, or 0 if nobody does
+}
+
+// Type returns the type of the FiefClaim event.
+func (f FiefClaim) Type() string {
+	return "fief-claim"
+}
+
+func init() {
+	message.Register(&FiefClaim{})
+}
@@ -0,0 +1,47 @@
+package event
+
+import (
+	"github.com/ketMix/ebijam25/internal/message"
+)
+
+// ItemSpawn represents an item drop coming into view on the ground.
+type ItemSpawn struct {
+	ID   int     `json:"id"`   // ID of the item drop
+	Item int     `json:"item"` // What the item is
+	X    float64 `json:"x"`
+	Y    float64 `json:"y"`
+}
+
+// Type returns the type of the ItemSpawn event.
+func (i ItemSpawn) Type() string {
+	return "item-spawn"
+}
+
+// ItemDespawn represents an item drop going out of view, whether it was picked up or just left behind.
+type ItemDespawn struct {
+	ID int `json:"id"` // ID of the item drop
+}
+
+// Type returns the type of the ItemDespawn event.
+func (i ItemDespawn) Type() string {
+	return "item-despawn"
+}
+
+// MobPickup represents a schlub in a mob picking up an item drop. The item lives in the schlub's ID, so the schlub's ID changes to the old one with the item.
+type MobPickup struct {
+	ID     int `json:"id"`     // ID of the mob
+	Drop   int `json:"drop"`   // ID of the item drop picked up
+	Schlub int `json:"schlub"` // ID of the schlub before picking it up
+	Item   int `json:"item"`   // What the item is
+}
+
+// Type returns the type of the MobPickup event.
+func (m MobPickup) Type() string {
+	return "mob-pickup"
+}
+
+func init() {
+	message.Register(&ItemSpawn{})
+	message.Register(&ItemDespawn{})
+	message.Register(&MobPickup{})
+}
@@ -8,9 +8,10 @@ import (
 
 // MetaJoin represents an event where a player joins the game with a username and unique ID.
 type MetaJoin struct {
-	Username string      `json:"username"`
-	ID       int         `json:"id"`
-	Color    color.NRGBA `json:"color"` // Color is the player's color in NRGBA format
+	Username  string      `json:"username"`
+	ID        int         `json:"id"`
+	Color     color.NRGBA `json:"color"`               // Color is the player's color in NRGBA format
+	Spectator bool        `json:"spectator,omitempty"` // Spectators have no mob and are just watching
 }
 
 // Type returns the type of the MetaJoin event.
@@ -20,12 +21,20 @@ func (m MetaJoin) Type() string {
 
 // MetaWelcome represents a welcome event for a player joining the game. It is the counterpart to MetaJoin.
 type MetaWelcome struct {
-	Username string      `json:"username"`
-	ID       int         `json:"id"`
-	Color    color.NRGBA `json:"color"` // Color is the player's color in NRGBA format
-	MobID    int         `json:"mobId"` // ID of the mob associated with the player
-	Seed     uint        `json:"seed"`  // Seed for this game's continent generation
-	Rate     int         `json:"rate"`  // Tick
+	Username  string      `json:"username"`
+	ID        int         `json:"id"`
+	Color     color.NRGBA `json:"color"`               // Color is the player's color in NRGBA format
+	MobID     int         `json:"mobId"`               // ID of the mob associated with the player
+	Seed      uint        `json:"seed"`                // Seed for this game's continent generation
+	Rate      int         `json:"rate"`                // Tick
+	Codec     string      `json:"codec"`               // Codec the server will use for all further messages
+	Version   int         `json:"version"`             // Protocol version the server speaks
+	Features  []string    `json:"features,omitempty"`  // Features both the server and client support
+	Token     string      `json:"token"`               // Token to present with request-resume if the connection drops
+	Resumed   bool        `json:"resumed,omitempty"`   // Whether this welcome is for a resumed session
+	Spectator bool        `json:"spectator,omitempty"` // Whether the player joined as a spectator, in which case MobID is 0
+	TableName string      `json:"tableName"`           // Name of the table joined
+	Code      string      `json:"code,omitempty"`      // Join code of the table, if it is private
 }
 
 // Type returns the type of the MetaWelcome event.
@@ -33,6 +42,48 @@ func (m MetaWelcome) Type() string {
 	return "meta-welcome"
 }
 
+// TableInfo describes a table in the lobby.
+type TableInfo struct {
+	ID         int    `json:"id"`
+	Name       string `json:"name"`
+	Players    int    `json:"players"`              // Players seated, not counting spectators
+	Spectators int    `json:"spectators,omitempty"` // Spectators watching
+	MaxPlayers int    `json:"maxPlayers"`
+}
+
+// MetaLobby lists the public tables, in answer to request-lobby or a join that didn't work out.
+type MetaLobby struct {
+	Tables []TableInfo `json:"tables"`
+	Error  string      `json:"error,omitempty"` // Why the last request failed, if it did
+}
+
+// Type returns the type of the MetaLobby event.
+func (m MetaLobby) Type() string {
+	return "meta-lobby"
+}
+
+// MetaTableCreated is sent in answer to request-create-table.
+type MetaTableCreated struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	Code string `json:"code,omitempty"` // Join code, if the table is private
+}
+
+// Type returns the type of the MetaTableCreated event.
+func (m MetaTableCreated) Type() string {
+	return "meta-table-created"
+}
+
+// MetaDisconnect is published locally by the client when the server closes the connection on it.
+type MetaDisconnect struct {
+	Reason string `json:"reason"` // Reason given by the server, if any
+}
+
+// Type returns the type of the MetaDisconnect event.
+func (m MetaDisconnect) Type() string {
+	return "meta-disconnect"
+}
+
 // MetaLeave represents an event where a player leaves the game.
 type MetaLeave struct {
 	ID int `json:"id"`
@@ -54,9 +105,23 @@ func (m MetaRefresh) Type() string {
 	return "meta-refresh"
 }
 
+// MetaPing is sent periodically by the server to keep the connection alive and measure latency. The client answers with request-pong.
+type MetaPing struct {
+	Seq int `json:"seq"`           // Sequence number to echo back
+	RTT int `json:"rtt,omitempty"` // Last measured round-trip time in milliseconds
+}
+
+// Type returns the type of the MetaPing event.
+func (m MetaPing) Type() string {
+	return "meta-ping"
+}
+
 func init() {
 	message.Register(&MetaJoin{})
 	message.Register(&MetaWelcome{})
+	message.Register(&MetaLobby{})
+	message.Register(&MetaTableCreated{})
 	message.Register(&MetaLeave{})
 	message.Register(&MetaRefresh{})
+	message.Register(&MetaPing{})
 }
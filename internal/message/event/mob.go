@@ -17,9 +17,11 @@ func (m MobMerge) Type() string {
 
 // MobSplit represents an event where a mob is split into another mob.
 type MobSplit struct {
-	ID int `json:"from"` // ID of the mob being split
-	// FIXME: This will not be cloned properly in Decode.
-	Schlubs []int `json:"schlubs"` // IDs of schlubs being split
+	ID      int     `json:"from"` // ID of the mob being split
+	To      int     `json:"to"`   // ID of the newly created mob
+	X       float64 `json:"x"`    // Position of the newly created mob
+	Y       float64 `json:"y"`
+	Schlubs []int   `json:"schlubs"` // IDs of schlubs being split
 }
 
 // Type returns the type of the MobSplit event.
@@ -29,10 +31,17 @@ func (m MobSplit) Type() string {
 
 // MobMove represents an event where a mob begins to move to a new position.
 type MobMove struct {
-	ID       int     `json:"id"`        // ID of the mob moving
-	TargetID int     `json:"target_id"` // ID of the target mob (if applicable)
-	X        float64 `json:"x"`
-	Y        float64 `json:"y"`
+	ID       int        `json:"id"`        // ID of the mob moving
+	TargetID int        `json:"target_id"` // ID of the target mob (if applicable)
+	X        float64    `json:"x"`
+	Y        float64    `json:"y"`
+	Path     []Waypoint `json:"path,omitempty"` // Waypoints the mob follows to get to X, Y, if it can't walk straight there.
+}
+
+// Waypoint is a point along a mob's path.
+type Waypoint struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
 }
 
 // Type returns the type of the MobMove event.
@@ -54,13 +63,12 @@ func (m MobPosition) Type() string {
 
 // MobSpawn represents an event where a new mob is spawned at a specific location. It is required that schlubs are created prior to this event.
 type MobSpawn struct {
-	ID    int     `json:"id"`    // ID of the spawned mob
-	Owner int     `json:"owner"` // ID of the owner (player) of the mob
-	X     float64 `json:"x"`
-	Y     float64 `json:"y"`
-	// FIXME: This will not be cloned properly in Decode.
-	Schlubs   []int `json:"schlubs"`         // IDs of schlubs associated with the mob
-	OuterKind int   `json:"outer,omitempty"` // Optional outer kind of the mob, used for formation
+	ID        int     `json:"id"`    // ID of the spawned mob
+	Owner     int     `json:"owner"` // ID of the owner (player) of the mob
+	X         float64 `json:"x"`
+	Y         float64 `json:"y"`
+	Schlubs   []int   `json:"schlubs"`         // IDs of schlubs associated with the mob
+	OuterKind int     `json:"outer,omitempty"` // Optional outer kind of the mob, used for formation
 }
 
 // Type returns the type of the MobSpawn event.
@@ -124,6 +132,29 @@ func (m MobFormation) Type() string {
 	return "mob-formation"
 }
 
+// MobAge represents schlubs in a mob getting older. Since the age lives in the schlub's ID, each schlub's ID changes to the old one with the new age.
+type MobAge struct {
+	ID   int   `json:"id"`      // ID of the mob.
+	IDs  []int `json:"schlubs"` // IDs of the schlubs before aging.
+	Ages []int `json:"ages"`    // New age of each schlub.
+}
+
+// Type returns the type of the MobAge event.
+func (m MobAge) Type() string {
+	return "mob-age"
+}
+
+// MobFood tells a mob's owner how much food it has left.
+type MobFood struct {
+	ID   int `json:"id"`   // ID of the mob.
+	Food int `json:"food"` // Food the mob is carrying.
+}
+
+// Type returns the type of the MobFood event.
+func (m MobFood) Type() string {
+	return "mob-food"
+}
+
 func init() {
 	message.Register(&MobMerge{})
 	message.Register(&MobSplit{})
@@ -135,4 +166,6 @@ func init() {
 	message.Register(&MobConvert{})
 	message.Register(&MobFormation{})
 	message.Register(&MobCreate{})
+	message.Register(&MobAge{})
+	message.Register(&MobFood{})
 }
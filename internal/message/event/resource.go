@@ -0,0 +1,46 @@
+package event
+
+import (
+	"github.com/ketMix/ebijam25/internal/message"
+)
+
+// ResourceSpawn represents a resource coming into view.
+type ResourceSpawn struct {
+	ID   int     `json:"id"`   // ID of the resource
+	Fief int     `json:"fief"` // Index of the fief the resource is in
+	X    float64 `json:"x"`
+	Y    float64 `json:"y"`
+	Food int     `json:"food"` // Food left in the resource
+}
+
+// Type returns the type of the ResourceSpawn event.
+func (r ResourceSpawn) Type() string {
+	return "resource-spawn"
+}
+
+// ResourceUpdate represents a resource being foraged.
+type ResourceUpdate struct {
+	ID   int `json:"id"`   // ID of the resource
+	Food int `json:"food"` // Food left in the resource
+}
+
+// Type returns the type of the ResourceUpdate event.
+func (r ResourceUpdate) Type() string {
+	return "resource-update"
+}
+
+// ResourceDespawn represents a resource going out of view, whether it was eaten up or just left behind.
+type ResourceDespawn struct {
+	ID int `json:"id"` // ID of the resource
+}
+
+// Type returns the type of the ResourceDespawn event.
+func (r ResourceDespawn) Type() string {
+	return "resource-despawn"
+}
+
+func init() {
+	message.Register(&ResourceSpawn{})
+	message.Register(&ResourceUpdate{})
+	message.Register(&ResourceDespawn{})
+}
@@ -0,0 +1,49 @@
+package event
+
+import (
+	"github.com/ketMix/ebijam25/internal/message"
+)
+
+// SettlementSpawn represents a settlement coming into view.
+type SettlementSpawn struct {
+	ID       int     `json:"id"`    // ID of the settlement
+	Owner    int     `json:"owner"` // ID of the player who founded it
+	Fief     int     `json:"fief"`  // Index of the fief the settlement is in
+	X        float64 `json:"x"`
+	Y        float64 `json:"y"`
+	Health   int     `json:"health"`             // Damage the settlement can still take
+	Failures int     `json:"failures,omitempty"` // Times in a row the settlement went hungry
+}
+
+// Type returns the type of the SettlementSpawn event.
+func (s SettlementSpawn) Type() string {
+	return "settlement-spawn"
+}
+
+// SettlementUpdate represents a settlement being besieged or going hungry.
+type SettlementUpdate struct {
+	ID       int `json:"id"`                 // ID of the settlement
+	Health   int `json:"health"`             // Damage the settlement can still take
+	Failures int `json:"failures,omitempty"` // Times in a row the settlement went hungry
+}
+
+// Type returns the type of the SettlementUpdate event.
+func (s SettlementUpdate) Type() string {
+	return "settlement-update"
+}
+
+// SettlementDespawn represents a settlement going out of view, whether it was torn down, abandoned, or just left behind.
+type SettlementDespawn struct {
+	ID int `json:"id"` // ID of the settlement
+}
+
+// Type returns the type of the SettlementDespawn event.
+func (s SettlementDespawn) Type() string {
+	return "settlement-despawn"
+}
+
+func init() {
+	message.Register(&SettlementSpawn{})
+	message.Register(&SettlementUpdate{})
+	message.Register(&SettlementDespawn{})
+}
@@ -0,0 +1,32 @@
+package event
+
+import (
+	"github.com/ketMix/ebijam25/internal/message"
+)
+
+// TechUnlock represents an event where the player acquires a skill from the tech tree.
+type TechUnlock struct {
+	Tech       string   `json:"tech"`                 // Name of the tech the skill belongs to
+	Skill      string   `json:"skill"`                // Name of the skill acquired
+	Constructs []string `json:"constructs,omitempty"` // Structures the skill unlocks
+}
+
+// Type returns the type of the TechUnlock event.
+func (t TechUnlock) Type() string {
+	return "tech-unlock"
+}
+
+// TechUsed represents an event where the player used a skill, starting its cooldown.
+type TechUsed struct {
+	Skill string `json:"skill"` // Name of the skill used
+}
+
+// Type returns the type of the TechUsed event.
+func (t TechUsed) Type() string {
+	return "tech-used"
+}
+
+func init() {
+	message.Register(&TechUnlock{})
+	message.Register(&TechUsed{})
+}
@@ -0,0 +1,15 @@
+package message
+
+// RegisteredTypes returns every registered message type, for tests outside the package.
+func RegisteredTypes() []string {
+	var types []string
+	for messageType := range registry {
+		types = append(types, messageType)
+	}
+	return types
+}
+
+// NewRegistered returns a fresh instance of the registered message type, for tests outside the package.
+func NewRegistered(messageType string) MessageI {
+	return registry[messageType]()
+}
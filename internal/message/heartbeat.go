@@ -0,0 +1,9 @@
+package message
+
+import "time"
+
+// PingInterval is how often the server pings players that negotiated FeatureHeartbeat.
+const PingInterval = time.Second * 2
+
+// IdleTimeout is how long either side waits to hear anything at all before giving up on the connection. It should comfortably cover a few missed pings.
+const IdleTimeout = time.Second * 10
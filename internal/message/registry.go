@@ -2,8 +2,14 @@ package message
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
 )
 
+// ErrUnknownType is returned by Decode when the message type has not been registered.
+var ErrUnknownType = errors.New("unknown message type")
+
 // MessageI is an interface that all message types must implement.
 type MessageI interface {
 	Type() string
@@ -15,14 +21,27 @@ type TypedMessage struct {
 	Data json.RawMessage `json:"data"` // Data of the message
 }
 
-var registry = map[string]MessageI{}
+// registry maps message types to factories that create a fresh instance of that message.
+var registry = map[string]func() MessageI{}
 
-// Register adds an MessageI instance to the registry. It panics if the message type is already registered.
+// Register adds a factory for the given MessageI's type to the registry. The message must be a pointer to a struct, as the factory allocates a new zero value of whatever it points to. It panics if the message type is already registered.
 func Register(message MessageI) {
 	if _, exists := registry[message.Type()]; exists {
 		panic("message type already registered: " + message.Type())
 	}
-	registry[message.Type()] = message
+	t := reflect.TypeOf(message)
+	if t.Kind() != reflect.Pointer {
+		panic("message type must be registered as a pointer: " + message.Type())
+	}
+	id := typeID(message.Type())
+	if other, exists := binaryTypes[id]; exists {
+		panic("message type binary id collides with " + other + ": " + message.Type())
+	}
+	elem := t.Elem()
+	registry[message.Type()] = func() MessageI {
+		return reflect.New(elem).Interface().(MessageI)
+	}
+	binaryTypes[id] = message.Type()
 }
 
 // Encode takes an MessageI instance and returns a byte slice containing the JSON-encoded message data.
@@ -46,23 +65,22 @@ func Encode(message MessageI) ([]byte, error) {
 	return data, nil
 }
 
-// Decode takes a byte slice containing a JSON-encoded message and returns the corresponding MessageI instance.
+// Decode takes a byte slice containing a JSON-encoded message and returns a newly allocated MessageI instance of the corresponding type. It returns ErrUnknownType if the type has not been registered.
 func Decode(data []byte) (MessageI, error) {
 	var typedMessage TypedMessage
 	if err := json.Unmarshal(data, &typedMessage); err != nil {
 		return nil, err
 	}
 
-	message, exists := registry[typedMessage.Type]
+	factory, exists := registry[typedMessage.Type]
 	if !exists {
-		return nil, nil // or return an error if you prefer
+		return nil, fmt.Errorf("%w: %q", ErrUnknownType, typedMessage.Type)
 	}
 
-	message2 := message // Create a new instance of the message type. FIXME: This won't clone contained slices...
+	message := factory()
 
 	// Unmarshal the data into the specific message type
-	err := json.Unmarshal(typedMessage.Data, message2)
-	if err != nil {
+	if err := json.Unmarshal(typedMessage.Data, message); err != nil {
 		return nil, err
 	}
 
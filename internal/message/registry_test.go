@@ -0,0 +1,90 @@
+package message_test
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/ketMix/ebijam25/internal/message"
+)
+
+// TestConcurrentDecode decodes every registered message from many goroutines at once under both codecs. Run it with -race.
+func TestConcurrentDecode(t *testing.T) {
+	type sample struct {
+		codec    message.Codec
+		original message.MessageI
+		data     []byte
+	}
+	var samples []sample
+	for _, codec := range []message.Codec{message.JSONCodec, message.BinaryCodec} {
+		for _, messageType := range message.RegisteredTypes() {
+			original := message.NewRegistered(messageType)
+			counter := 0
+			populate(reflect.ValueOf(original).Elem(), &counter)
+			data, err := codec.Encode(original)
+			if err != nil {
+				t.Fatalf("%s encode %s: %v", codec.Name(), messageType, err)
+			}
+			samples = append(samples, sample{codec, original, data})
+		}
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan string, 16)
+	for worker := range 16 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 20 * len(samples) {
+				s := samples[(i+worker)%len(samples)]
+				decoded, err := s.codec.Decode(s.data)
+				if err != nil {
+					errs <- s.codec.Name() + " " + s.original.Type() + ": " + err.Error()
+					return
+				}
+				if decoded.Type() != s.original.Type() {
+					errs <- s.codec.Name() + " decoded " + s.original.Type() + " as " + decoded.Type()
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+func TestDecodeFresh(t *testing.T) {
+	for _, codec := range []message.Codec{message.JSONCodec, message.BinaryCodec} {
+		for _, messageType := range message.RegisteredTypes() {
+			data, err := codec.Encode(message.NewRegistered(messageType))
+			if err != nil {
+				t.Fatalf("%s encode %s: %v", codec.Name(), messageType, err)
+			}
+			first, err1 := codec.Decode(data)
+			second, err2 := codec.Decode(data)
+			if err1 != nil || err2 != nil {
+				t.Fatalf("%s decode %s: %v, %v", codec.Name(), messageType, err1, err2)
+			}
+			// Go hands out the same address for every empty struct, and there's nothing in them to share anyway.
+			if reflect.TypeOf(first).Elem().Size() == 0 {
+				continue
+			}
+			if reflect.ValueOf(first).Pointer() == reflect.ValueOf(second).Pointer() {
+				t.Errorf("%s decoded %s into the same instance twice", codec.Name(), messageType)
+			}
+		}
+	}
+}
+
+func TestDecodeUnknown(t *testing.T) {
+	if _, err := message.Decode([]byte(`{"type":"no-such-message","data":{}}`)); !errors.Is(err, message.ErrUnknownType) {
+		t.Errorf("JSON decode of an unknown type gave %v", err)
+	}
+	if _, err := message.DecodeBinary([]byte{0x01}); !errors.Is(err, message.ErrUnknownType) {
+		t.Errorf("binary decode of an unknown type gave %v", err)
+	}
+}
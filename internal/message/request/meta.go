@@ -9,7 +9,13 @@ import (
 // Join represents a request to join the game with a username.
 type Join struct {
 	Username string      `json:"username"`
-	Color    color.NRGBA `json:"color"` // Color is the player's color in NRGBA format.
+	Color    color.NRGBA `json:"color"`              // Color is the player's color in NRGBA format.
+	Codecs   []string    `json:"codecs,omitempty"`   // Codecs the client can speak, in order of preference.
+	Version  int         `json:"version"`            // Protocol version the client speaks, see message.ProtocolVersion.
+	Features []string    `json:"features,omitempty"` // Optional features the client supports, see message.Features.
+	Spectate bool        `json:"spectate,omitempty"` // Spectate joins without a mob, just to watch.
+	Table    int         `json:"table,omitempty"`    // ID of the table to join, 0 for any open public table.
+	Code     string      `json:"code,omitempty"`     // Join code, needed for private tables. A code on its own is enough to find its table.
 }
 
 // Type returns the type of the Join request.
@@ -17,6 +23,38 @@ func (j Join) Type() string {
 	return "request-join"
 }
 
+// Lobby represents a request for the list of tables. It can be sent any number of times before joining.
+type Lobby struct {
+}
+
+// Type returns the type of the Lobby request.
+func (l Lobby) Type() string {
+	return "request-lobby"
+}
+
+// CreateTable represents a request to create a new table. The server answers with meta-table-created, after which the table can be joined like any other.
+type CreateTable struct {
+	Name       string `json:"name"`              // Name shown in the lobby
+	MaxPlayers int    `json:"maxPlayers"`        // Max players, not counting spectators. 0 for the server's default.
+	Private    bool   `json:"private,omitempty"` // Private tables are left out of the lobby and need their join code
+}
+
+// Type returns the type of the CreateTable request.
+func (c CreateTable) Type() string {
+	return "request-create-table"
+}
+
+// Resume represents a request to reattach to a session that was dropped, using the token handed out in MetaWelcome.
+type Resume struct {
+	Token   string `json:"token"`   // Token from the last MetaWelcome
+	Version int    `json:"version"` // Protocol version the client speaks, see message.ProtocolVersion.
+}
+
+// Type returns the type of the Resume request.
+func (r Resume) Type() string {
+	return "request-resume"
+}
+
 // Leave represents a request to leave the game.
 type Leave struct {
 }
@@ -26,7 +64,32 @@ func (l Leave) Type() string {
 	return "request-leave"
 }
 
+// Pong answers a MetaPing.
+type Pong struct {
+	Seq int `json:"seq"` // Sequence number of the ping being answered
+}
+
+// Type returns the type of the Pong request.
+func (p Pong) Type() string {
+	return "request-pong"
+}
+
+// Follow represents a spectator's request to see what the given player sees. An ID of 0 goes back to seeing everything.
+type Follow struct {
+	ID int `json:"id"` // ID of the player to follow
+}
+
+// Type returns the type of the Follow request.
+func (f Follow) Type() string {
+	return "request-follow"
+}
+
 func init() {
 	message.Register(&Join{})
+	message.Register(&Lobby{})
+	message.Register(&CreateTable{})
+	message.Register(&Resume{})
 	message.Register(&Leave{})
+	message.Register(&Pong{})
+	message.Register(&Follow{})
 }
@@ -4,7 +4,7 @@ import "github.com/ketMix/ebijam25/internal/message"
 
 // Split represents a request to split a mob into a separate mob.
 type Split struct {
-	// FIXME: This will not be cloned properly in Decode.
+	ID      int   `json:"id"`      // ID of the mob to split from
 	Schlubs []int `json:"schlubs"` // IDs of schlubs being split
 }
 
@@ -13,10 +13,22 @@ func (s Split) Type() string {
 	return "request-split"
 }
 
+// Merge represents a request to have one mob join up with another mob owned by the same player.
+type Merge struct {
+	From int `json:"from"` // ID of the mob to be merged
+	To   int `json:"to"`   // ID of the mob to be merged into
+}
+
+// Type returns the type of the Merge request.
+func (m Merge) Type() string {
+	return "request-merge"
+}
+
 // Move represents a request to move a mob towards a new position.
 type Move struct {
-	X float64 `json:"x"` // X coordinate to move to
-	Y float64 `json:"y"` // Y coordinate to move to
+	ID int     `json:"id"` // ID of the mob to move
+	X  float64 `json:"x"`  // X coordinate to move to
+	Y  float64 `json:"y"`  // Y coordinate to move to
 }
 
 // Type returns the type of the Move request.
@@ -26,7 +38,7 @@ func (m Move) Type() string {
 
 // Formation represents a request to adjust the formation of a mob to have the schlubs organized from center outwards.
 type Formation struct {
-	// FIXME: This will not be cloned properly in Decode.
+	ID int `json:"id"` // ID of the mob to change formation
 	//Order []string `json:"order,omitempty"` // Order of schlubs from center outwards.
 }
 
@@ -37,7 +49,8 @@ func (f Formation) Type() string {
 
 // Construct represents a request to construct a specific type of structure.
 type Construct struct {
-	Caravan int `json:"caravan"` // Caravan to construct -- see last 3 schlub kinds.
+	Caravan    int  `json:"caravan"`              // Caravan to construct -- see last 3 schlub kinds.
+	Settlement bool `json:"settlement,omitempty"` // Found a settlement where the mob stands instead of a caravan.
 }
 
 // Type returns the type of the Construct request.
@@ -45,8 +58,9 @@ func (c Construct) Type() string {
 	return "request-construct"
 }
 
+// TechUse represents a request to acquire a skill from the tech tree, or to use it if it's already acquired and usable.
 type TechUse struct {
-	Tech string `json:"tech"` // Name of the technology to use (e.g., "archery", "fishing")
+	Tech string `json:"tech"` // Name of the skill to acquire or use (e.g., "recruit", "bless")
 }
 
 // Type returns the type of the TechUse request.
@@ -56,6 +70,7 @@ func (t TechUse) Type() string {
 
 func init() {
 	message.Register(&Split{})
+	message.Register(&Merge{})
 	message.Register(&Move{})
 	message.Register(&Formation{})
 	message.Register(&Construct{})
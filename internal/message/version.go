@@ -0,0 +1,42 @@
+package message
+
+import "slices"
+
+// ProtocolVersion is the version of the messages in this build. Bump it whenever messages change in a way that older builds can't cope with.
+const ProtocolVersion = 2
+
+// MinProtocolVersion is the oldest protocol version a server will still talk to.
+const MinProtocolVersion = 2 // Version 2 moved the schlub ID bits around to make room for ages.
+
+// Features are optional capabilities that both sides must agree on before they are used.
+const (
+	FeatureSplit     = "split"     // Mobs can be split with request-split.
+	FeatureMerge     = "merge"     // Mobs can be merged with request-merge.
+	FeatureResume    = "resume"    // Dropped sessions can be picked back up with request-resume.
+	FeatureHeartbeat = "heartbeat" // The server sends meta-ping and expects request-pong back.
+	FeatureSpectate  = "spectate"  // Spectators can pick whose vision to follow with request-follow.
+)
+
+// features are all features supported by this build.
+var features = []string{FeatureSplit, FeatureMerge, FeatureResume, FeatureHeartbeat, FeatureSpectate}
+
+// Features returns all features supported by this build.
+func Features() []string {
+	return slices.Clone(features)
+}
+
+// CompatibleVersion returns true if a peer speaking the given protocol version can talk to us.
+func CompatibleVersion(version int) bool {
+	return version >= MinProtocolVersion && version <= ProtocolVersion
+}
+
+// NegotiateFeatures returns the requested features that this build also supports.
+func NegotiateFeatures(requested []string) []string {
+	var negotiated []string
+	for _, feature := range requested {
+		if slices.Contains(features, feature) && !slices.Contains(negotiated, feature) {
+			negotiated = append(negotiated, feature)
+		}
+	}
+	return negotiated
+}
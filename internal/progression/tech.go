@@ -1,27 +1,223 @@
 package progression
 
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"slices"
+	"sort"
+
+	"github.com/ketMix/ebijam25/internal/world"
+)
+
 const (
 	TechNameKnight = "knight"
 	TechNameMonk   = "monk"
 	TechNameNomad  = "nomad"
 )
 
+// Things a skill can unlock for construction.
+const (
+	ConstructCaravanVagrant = "caravan-vagrant"
+	ConstructCaravanMonk    = "caravan-monk"
+	ConstructCaravanWarrior = "caravan-warrior"
+	ConstructSettlement     = "settlement"
+)
+
+// CaravanConstructs maps the caravans request.Construct can ask for to the tech tree's name for them.
+var CaravanConstructs = map[world.SchlubID]string{
+	world.SchlubKindCaravanVagrant: ConstructCaravanVagrant,
+	world.SchlubKindCaravanMonk:    ConstructCaravanMonk,
+	world.SchlubKindCaravanWarrior: ConstructCaravanWarrior,
+}
+
+var (
+	ErrUnknownSkill    = errors.New("unknown skill")
+	ErrAlreadyAcquired = errors.New("skill already acquired")
+	ErrMissingPrereqs  = errors.New("skill prerequisites not met")
+	ErrNotAcquired     = errors.New("skill not acquired")
+	ErrNotUsable       = errors.New("skill cannot be used")
+)
+
 type TechSkill struct {
-	name        string
-	description string
-	acquired    bool
-	cost        int
-	prereqs     []string
-	usable      bool     // Whether the skill can be used directly by the player
-	constructs  []string // Structures that can be constructed with this skill
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Cost        int      `json:"cost"`       // Schlubs the player's mob gives up to acquire the skill
+	Prereqs     []string `json:"prereqs"`    // Skills that must be acquired first
+	Innate      bool     `json:"innate"`     // Whether every player starts with the skill
+	Usable      bool     `json:"usable"`     // Whether the skill can be used directly by the player
+	Cooldown    float64  `json:"cooldown"`   // Seconds between uses, for usable skills
+	Constructs  []string `json:"constructs"` // Structures that can be constructed with this skill
 }
 
 type Tech struct {
-	name        string
-	description string
-	skills      []TechSkill
+	Name        string      `json:"-"`
+	Description string      `json:"description"`
+	Skills      []TechSkill `json:"skills"`
 }
 
 type TechTree struct {
 	techs map[string]Tech // Map of tech names to Tech objects
 }
+
+//go:embed techs.json
+var techsFile embed.FS
+
+// LoadTechTree loads the tech tree shipped with the game.
+func LoadTechTree() (*TechTree, error) {
+	data, err := techsFile.ReadFile("techs.json")
+	if err != nil {
+		return nil, err
+	}
+	return ParseTechTree(data)
+}
+
+// ParseTechTree parses a tech tree from JSON, keyed by tech name. Every skill name must be unique across the whole tree, every prerequisite must exist, and nothing may cost less than nothing.
+func ParseTechTree(data []byte) (*TechTree, error) {
+	var techs map[string]Tech
+	if err := json.Unmarshal(data, &techs); err != nil {
+		return nil, err
+	}
+	tree := &TechTree{techs: make(map[string]Tech)}
+	seen := make(map[string]bool)
+	for name, tech := range techs {
+		tech.Name = name
+		tree.techs[name] = tech
+		for _, skill := range tech.Skills {
+			if seen[skill.Name] {
+				return nil, errors.New("duplicate skill in tech tree: " + skill.Name)
+			}
+			seen[skill.Name] = true
+			if skill.Cost < 0 {
+				return nil, errors.New("skill " + skill.Name + " has a negative cost")
+			}
+		}
+	}
+	for _, tech := range tree.techs {
+		for _, skill := range tech.Skills {
+			for _, prereq := range skill.Prereqs {
+				if !seen[prereq] {
+					return nil, errors.New("skill " + skill.Name + " requires unknown skill " + prereq)
+				}
+			}
+		}
+	}
+	return tree, nil
+}
+
+// CheckUsable returns an error naming the first usable skill that hasEffect says does nothing when used.
+func (t *TechTree) CheckUsable(hasEffect func(skill string) bool) error {
+	for _, name := range t.Techs() {
+		for _, skill := range t.techs[name].Skills {
+			if skill.Usable && !hasEffect(skill.Name) {
+				return errors.New("usable skill has no effect: " + skill.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// Techs returns the names of all techs in the tree, sorted.
+func (t *TechTree) Techs() []string {
+	var names []string
+	for name := range t.techs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Tech returns the tech with the given name.
+func (t *TechTree) Tech(name string) (Tech, bool) {
+	tech, ok := t.techs[name]
+	return tech, ok
+}
+
+// Skill returns the skill with the given name along with the tech it belongs to.
+func (t *TechTree) Skill(name string) (*TechSkill, *Tech) {
+	for _, tech := range t.techs {
+		for i := range tech.Skills {
+			if tech.Skills[i].Name == name {
+				return &tech.Skills[i], &tech
+			}
+		}
+	}
+	return nil, nil
+}
+
+// Innate returns the names of the skills every player starts with.
+func (t *TechTree) Innate() []string {
+	var skills []string
+	for _, name := range t.Techs() {
+		for _, skill := range t.techs[name].Skills {
+			if skill.Innate {
+				skills = append(skills, skill.Name)
+			}
+		}
+	}
+	return skills
+}
+
+// TechState is what a single player has acquired from a tech tree.
+type TechState struct {
+	acquired []string
+}
+
+// Has returns true if the skill has been acquired.
+func (s *TechState) Has(skill string) bool {
+	return slices.Contains(s.acquired, skill)
+}
+
+// Acquired returns the names of the acquired skills, in the order they were acquired.
+func (s *TechState) Acquired() []string {
+	return slices.Clone(s.acquired)
+}
+
+// Add marks the skill as acquired without any checks. Use CanAcquire first.
+func (s *TechState) Add(skill string) {
+	if !s.Has(skill) {
+		s.acquired = append(s.acquired, skill)
+	}
+}
+
+// CanAcquire returns the skill if it can be acquired next, or why it can't. It does not check the cost.
+func (s *TechState) CanAcquire(tree *TechTree, name string) (*TechSkill, error) {
+	skill, _ := tree.Skill(name)
+	if skill == nil {
+		return nil, ErrUnknownSkill
+	}
+	if s.Has(name) {
+		return skill, ErrAlreadyAcquired
+	}
+	for _, prereq := range skill.Prereqs {
+		if !s.Has(prereq) {
+			return skill, ErrMissingPrereqs
+		}
+	}
+	return skill, nil
+}
+
+// CanUse returns the skill if it has been acquired and is usable, or why it can't be used. It does not check the cooldown.
+func (s *TechState) CanUse(tree *TechTree, name string) (*TechSkill, error) {
+	skill, _ := tree.Skill(name)
+	if skill == nil {
+		return nil, ErrUnknownSkill
+	}
+	if !s.Has(name) {
+		return skill, ErrNotAcquired
+	}
+	if !skill.Usable {
+		return skill, ErrNotUsable
+	}
+	return skill, nil
+}
+
+// CanConstruct returns true if any acquired skill unlocks the construct.
+func (s *TechState) CanConstruct(tree *TechTree, construct string) bool {
+	for _, name := range s.acquired {
+		if skill, _ := tree.Skill(name); skill != nil && slices.Contains(skill.Constructs, construct) {
+			return true
+		}
+	}
+	return false
+}
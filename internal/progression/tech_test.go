@@ -0,0 +1,150 @@
+package progression
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestLoadTechTree(t *testing.T) {
+	tree, err := LoadTechTree()
+	if err != nil {
+		t.Fatalf("shipped tech tree doesn't parse: %v", err)
+	}
+	for _, name := range []string{TechNameKnight, TechNameMonk, TechNameNomad} {
+		if tech, ok := tree.Tech(name); !ok || tech.Name != name || len(tech.Skills) == 0 {
+			t.Errorf("tech %q is missing or empty", name)
+		}
+	}
+	if len(tree.Innate()) == 0 {
+		t.Errorf("nobody starts with anything")
+	}
+	// Every caravan must be buildable by someone who has everything.
+	var state TechState
+	for _, name := range tree.Techs() {
+		tech, _ := tree.Tech(name)
+		for _, skill := range tech.Skills {
+			state.Add(skill.Name)
+		}
+	}
+	for _, construct := range CaravanConstructs {
+		if !state.CanConstruct(tree, construct) {
+			t.Errorf("no skill unlocks %q", construct)
+		}
+	}
+}
+
+func TestParseTechTreeRejects(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want string // Part of the error expected.
+	}{
+		{
+			name: "not json",
+			json: `{"nomad": [`,
+			want: "unexpected end",
+		},
+		{
+			name: "unknown prereq",
+			json: `{"nomad": {"skills": [{"name": "recruit", "prereqs": ["wandering"]}]}}`,
+			want: "skill recruit requires unknown skill wandering",
+		},
+		{
+			name: "duplicate name in one tech",
+			json: `{"nomad": {"skills": [{"name": "recruit"}, {"name": "recruit"}]}}`,
+			want: "duplicate skill in tech tree: recruit",
+		},
+		{
+			name: "duplicate name across techs",
+			json: `{"nomad": {"skills": [{"name": "recruit"}]}, "monk": {"skills": [{"name": "recruit"}]}}`,
+			want: "duplicate skill in tech tree: recruit",
+		},
+		{
+			name: "negative cost",
+			json: `{"nomad": {"skills": [{"name": "recruit", "cost": -5}]}}`,
+			want: "skill recruit has a negative cost",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tree, err := ParseTechTree([]byte(tt.json))
+			if err == nil {
+				t.Fatalf("parsed into %v", tree)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error %q, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckUsable(t *testing.T) {
+	tree, err := ParseTechTree([]byte(`{"nomad": {"skills": [
+		{"name": "wandering", "innate": true},
+		{"name": "recruit", "usable": true, "prereqs": ["wandering"]},
+		{"name": "rally", "usable": true}
+	]}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	effects := map[string]bool{"recruit": true, "rally": true}
+	hasEffect := func(skill string) bool { return effects[skill] }
+	// Wandering can't be used, so it doesn't need an effect.
+	if err := tree.CheckUsable(hasEffect); err != nil {
+		t.Errorf("every usable skill has an effect, but got %v", err)
+	}
+	delete(effects, "rally")
+	if err := tree.CheckUsable(hasEffect); err == nil || !strings.Contains(err.Error(), "rally") {
+		t.Errorf("usable skill without an effect got %v", err)
+	}
+	delete(effects, "recruit")
+	effects["rally"] = true
+	if err := tree.CheckUsable(hasEffect); err == nil || !strings.Contains(err.Error(), "recruit") {
+		t.Errorf("usable skill without an effect got %v", err)
+	}
+}
+
+func TestTechState(t *testing.T) {
+	tree, err := ParseTechTree([]byte(`{"nomad": {"skills": [
+		{"name": "wandering", "innate": true, "constructs": ["caravan-vagrant"]},
+		{"name": "recruit", "usable": true, "prereqs": ["wandering"], "cost": 5},
+		{"name": "settle", "prereqs": ["wandering"], "constructs": ["settlement"]}
+	]}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var state TechState
+	if _, err := state.CanAcquire(tree, "recruit"); !errors.Is(err, ErrMissingPrereqs) {
+		t.Errorf("acquiring without prereqs got %v", err)
+	}
+	if _, err := state.CanAcquire(tree, "nonsense"); !errors.Is(err, ErrUnknownSkill) {
+		t.Errorf("acquiring an unknown skill got %v", err)
+	}
+	for _, name := range tree.Innate() {
+		state.Add(name)
+	}
+	if !state.CanConstruct(tree, ConstructCaravanVagrant) || state.CanConstruct(tree, ConstructSettlement) {
+		t.Errorf("innate skills unlocked the wrong things")
+	}
+	if skill, err := state.CanAcquire(tree, "recruit"); err != nil || skill.Cost != 5 {
+		t.Errorf("acquiring recruit got %v, %v", skill, err)
+	}
+	if _, err := state.CanUse(tree, "recruit"); !errors.Is(err, ErrNotAcquired) {
+		t.Errorf("using an unacquired skill got %v", err)
+	}
+	state.Add("recruit")
+	state.Add("recruit")
+	if _, err := state.CanAcquire(tree, "recruit"); !errors.Is(err, ErrAlreadyAcquired) {
+		t.Errorf("acquiring twice got %v", err)
+	}
+	if _, err := state.CanUse(tree, "recruit"); err != nil {
+		t.Errorf("using recruit got %v", err)
+	}
+	if _, err := state.CanUse(tree, "wandering"); !errors.Is(err, ErrNotUsable) {
+		t.Errorf("using a passive skill got %v", err)
+	}
+	if got := state.Acquired(); len(got) != 2 || got[0] != "wandering" || got[1] != "recruit" {
+		t.Errorf("acquired %v", got)
+	}
+}
@@ -0,0 +1,43 @@
+package server
+
+import (
+	"slices"
+
+	"github.com/ketMix/ebijam25/internal/message/event"
+	"github.com/ketMix/ebijam25/internal/world"
+)
+
+// AgeSchlubs ages every schlub whose birthday falls on the given round and buries those who were already as old as they get.
+func (t *Table) AgeSchlubs(round int) {
+	// Old age can despawn mobs, so go over a copy.
+	for _, mob := range slices.Clone(t.Continent.Mobs) {
+		var ids, ages []int
+		var died []world.SchlubID
+		for i, schlub := range mob.Schlubs {
+			if !schlub.Birthday(round) {
+				continue
+			}
+			if schlub.AgeID() >= world.AgeMax {
+				died = append(died, schlub)
+				continue
+			}
+			mob.Schlubs[i].SetAgeID(schlub.AgeID() + 1)
+			ids = append(ids, int(schlub))
+			ages = append(ages, mob.Schlubs[i].AgeID())
+		}
+		if len(ids) > 0 {
+			t.SendVisibleMobEvent(mob, &event.MobAge{
+				ID:   mob.ID,
+				IDs:  ids,
+				Ages: ages,
+			})
+		}
+		if len(died) > 0 {
+			t.removeSchlubs(mob, died)
+			t.DropItems(mob, died)
+			if len(mob.Schlubs) == 0 {
+				t.DespawnMob(mob)
+			}
+		}
+	}
+}
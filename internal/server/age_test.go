@@ -0,0 +1,56 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/ketMix/ebijam25/internal/world"
+)
+
+// aged returns the schlub as the given kind and age, carrying the item.
+func aged(s world.SchlubID, kind world.SchlubID, age int, item world.Item) world.SchlubID {
+	s.SetKindID(int(kind))
+	s.SetAgeID(age)
+	s.SetItemID(int(item))
+	return s
+}
+
+func TestAgeSchlubs(t *testing.T) {
+	table := emptyTable()
+	x, y := middle(table, 0)
+	ids := world.SchlubID(0).NextFamily().NextSchlubs(4)
+	leader := aged(ids[0], world.SchlubKindPlayer, 0, world.ItemNone)
+	child := aged(ids[1], world.SchlubKindVagrant, 0, world.ItemNone)
+	elder := aged(ids[2], world.SchlubKindMonk, world.AgeMax, world.ItemBanner)
+	mob := table.Continent.NewMob(1, 1, x, y)
+	mob.AddSchlub(leader, child, elder)
+
+	// Everyone has a birthday somewhere in a full cycle.
+	for round := range world.AgeInterval {
+		table.AgeSchlubs(round)
+	}
+	if len(mob.Schlubs) != 2 {
+		t.Fatalf("mob has %d schlubs left, want 2", len(mob.Schlubs))
+	}
+	if mob.Schlubs[0] != leader {
+		t.Errorf("leader aged into %v", mob.Schlubs[0])
+	}
+	if got := mob.Schlubs[1]; got.AgeID() != 1 || got.FamilyID() != child.FamilyID() || got.SchlubID() != child.SchlubID() || got.KindID() != child.KindID() {
+		t.Errorf("child grew up into %v", got)
+	}
+	if len(table.Continent.Items) != 1 || table.Continent.Items[0].Item != world.ItemBanner {
+		t.Errorf("elder left %d items behind, want their banner", len(table.Continent.Items))
+	}
+
+	// A mob of nobody but the old dies out with them.
+	old := table.Continent.NewMob(0, 2, x, y)
+	old.AddSchlub(aged(ids[3], world.SchlubKindWarrior, world.AgeMax, world.ItemNone))
+	for round := range world.AgeInterval {
+		table.AgeSchlubs(round)
+	}
+	if table.Continent.FindMob(old.ID) != nil {
+		t.Errorf("mob of the dead is still around")
+	}
+	if table.Continent.FindMob(mob.ID) != mob || mob.Schlubs[1].AgeID() != 2 {
+		t.Errorf("the living didn't carry on")
+	}
+}
@@ -0,0 +1,61 @@
+package server
+
+import (
+	"slices"
+
+	"github.com/ketMix/ebijam25/internal/message/event"
+	"github.com/ketMix/ebijam25/internal/world"
+)
+
+// Clash resolves the attacker running into the defender with the table's combat rules, then applies the result and tells everyone watching.
+func (t *Table) Clash(attacker, defender *world.Mob) {
+	// Either side may have been despawned by an earlier clash.
+	if t.Continent.FindMob(attacker.ID) == nil || t.Continent.FindMob(defender.ID) == nil {
+		return
+	}
+	result := t.Combat.Resolve(attacker, defender)
+
+	if len(result.Converted) > 0 {
+		defender.RemoveSchlub(result.Converted...)
+		attacker.AddSchlub(result.Converted...)
+		t.SendVisibleMobsEvent(&event.MobConvert{
+			From: defender.ID,
+			To:   attacker.ID,
+			IDs:  schlubInts(result.Converted),
+		}, attacker, defender)
+	}
+	if len(result.Slain) > 0 {
+		defender.RemoveSchlub(result.Slain...)
+		t.DropItems(defender, result.Slain)
+		t.SendVisibleMobEvent(defender, &event.MobDamage{
+			ID:         defender.ID,
+			AttackerID: attacker.ID,
+			IDs:        schlubInts(result.Slain),
+		})
+	}
+	if result.Despawn {
+		t.DespawnMob(defender)
+		// TODO: Maybe we should also check if the player has any mobs left.
+	}
+}
+
+// DespawnMob removes the mob from the continent and from the view of everyone who could see it.
+func (t *Table) DespawnMob(mob *world.Mob) {
+	t.Continent.RemoveMob(mob)
+	for _, player := range t.players {
+		if slices.Contains(player.VisibleMobIDs, mob.ID) {
+			t.HideMobFrom(player, mob)
+			player.VisibleMobIDs = slices.DeleteFunc(player.VisibleMobIDs, func(id world.ID) bool {
+				return id == mob.ID
+			})
+		}
+	}
+}
+
+func schlubInts(schlubs []world.SchlubID) []int {
+	ids := make([]int, len(schlubs))
+	for i, schlub := range schlubs {
+		ids[i] = int(schlub)
+	}
+	return ids
+}
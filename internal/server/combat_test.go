@@ -0,0 +1,39 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/ketMix/ebijam25/internal/world"
+)
+
+func TestClashDespawned(t *testing.T) {
+	table := emptyTable()
+	x, y := middle(table, 0)
+	attacker := table.Continent.NewMob(1, 1, x, y)
+	attacker.OuterKind = world.SchlubKindPlayer
+	attacker.AddSchlub(world.SchlubID(1))
+	defender := table.Continent.NewMob(0, 2, x, y)
+	defender.AddSchlub(world.SchlubID(2), world.SchlubID(3))
+
+	// An attacker that's gone doesn't get to convert anyone.
+	table.Continent.RemoveMob(attacker)
+	table.Clash(attacker, defender)
+	if len(attacker.Schlubs) != 1 || len(defender.Schlubs) != 2 || table.Continent.FindMob(defender.ID) != defender {
+		t.Errorf("despawned attacker clashed, %d vs %d schlubs", len(attacker.Schlubs), len(defender.Schlubs))
+	}
+
+	// Nor does anyone get to fight a defender that's gone.
+	table.Continent.AddMob(attacker)
+	table.Continent.RemoveMob(defender)
+	table.Clash(attacker, defender)
+	if len(attacker.Schlubs) != 1 || len(defender.Schlubs) != 2 {
+		t.Errorf("clashed with a despawned defender, %d vs %d schlubs", len(attacker.Schlubs), len(defender.Schlubs))
+	}
+
+	// Both around, the player wins everyone over and the defender is no more.
+	table.Continent.AddMob(defender)
+	table.Clash(attacker, defender)
+	if len(attacker.Schlubs) != 3 || table.Continent.FindMob(defender.ID) != nil {
+		t.Errorf("clash left %d vs %d schlubs", len(attacker.Schlubs), len(defender.Schlubs))
+	}
+}
@@ -4,8 +4,4 @@ func (t *Table) UpdateContinent() {
 	for _, mob := range t.Continent.Mobs {
 		t.UpdateMob(mob)
 	}
-
-	/*for _, resource := range g.Resources {
-		// Update resource logic
-	}*/
 }
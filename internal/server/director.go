@@ -9,13 +9,26 @@ import (
 const (
 	MobTick           = 90
 	ResourceTick      = 60
+	AgeTick           = 20 // Ticks per aging round, so any one schlub ages every AgeTick*world.AgeInterval ticks.
+	ItemTick          = 100
+	FoodTick          = 100 // Ticks per upkeep round.
+	SettlementTick    = 20  // Ticks per settlement round.
+	ClaimTick         = 20  // Ticks per fief claim round.
+	MaxItemDrops      = 150 // Items stop turning up on the map once this many are lying around.
 	MaxSchlubsToSpawn = 100
 	MobStartingCount  = 200
+	spawnAttempts     = 10 // Random spots tried before giving up on finding dry land.
 )
 
 type Timers struct {
 	mobTimer      int
 	resourceTimer int
+	ageTimer      int
+	itemTimer     int
+	foodTimer     int
+	settleTimer   int
+	claimTimer    int
+	ageRound      int
 }
 
 type Director struct {
@@ -41,9 +54,18 @@ func (d *Director) Setup() {
 	}
 }
 
+// GetSpawnPosition returns a random spot to spawn something, trying to keep it out of the water.
 func (d *Director) GetSpawnPosition() (float64, float64) {
-	return rand.Float64() * world.ContinentPixelSpan, rand.Float64() * world.ContinentPixelSpan
+	var x, y float64
+	for range spawnAttempts {
+		x, y = rand.Float64()*world.ContinentPixelSpan, rand.Float64()*world.ContinentPixelSpan
+		if d.table.Continent.TerrainAt(x, y) != world.TerrainWater {
+			break
+		}
+	}
+	return x, y
 }
+
 func (d *Director) AddMobs() {
 	// Spawn a family unit.
 	t := d.table
@@ -72,9 +94,25 @@ func (d *Director) AddMobs() {
 	t.log.Debug("added mob", "id", mob.ID, "x", posX, "y", posY, "schlubs", len(mob.Schlubs))
 }
 
+// AddItem leaves a random item lying somewhere on the map for someone to find.
+func (d *Director) AddItem() {
+	t := d.table
+	if len(t.Continent.Items) >= MaxItemDrops {
+		return
+	}
+	item := world.Item(t.Continent.Fate.NumGen.Intn(int(world.ItemCount)-1) + 1)
+	x, y := d.GetSpawnPosition()
+	t.DropItem(item, x, y)
+}
+
 func (d *Director) Update() {
 	d.timers.mobTimer++
 	d.timers.resourceTimer++
+	d.timers.ageTimer++
+	d.timers.itemTimer++
+	d.timers.foodTimer++
+	d.timers.settleTimer++
+	d.timers.claimTimer++
 
 	if d.timers.mobTimer >= MobTick {
 		d.AddMobs()
@@ -82,6 +120,34 @@ func (d *Director) Update() {
 	}
 
 	if d.timers.resourceTimer >= ResourceTick {
+		d.AddResource()
+		d.table.Forage()
 		d.timers.resourceTimer = 0
 	}
+
+	if d.timers.itemTimer >= ItemTick {
+		d.AddItem()
+		d.timers.itemTimer = 0
+	}
+
+	if d.timers.foodTimer >= FoodTick {
+		d.table.FeedMobs()
+		d.timers.foodTimer = 0
+	}
+
+	if d.timers.settleTimer >= SettlementTick {
+		d.table.UpdateSettlements()
+		d.timers.settleTimer = 0
+	}
+
+	if d.timers.claimTimer >= ClaimTick {
+		d.table.ClaimFiefs()
+		d.timers.claimTimer = 0
+	}
+
+	if d.timers.ageTimer >= AgeTick {
+		d.table.AgeSchlubs(d.timers.ageRound)
+		d.timers.ageTimer = 0
+		d.timers.ageRound++
+	}
 }
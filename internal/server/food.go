@@ -0,0 +1,47 @@
+package server
+
+import (
+	"slices"
+
+	"github.com/ketMix/ebijam25/internal/message/event"
+	"github.com/ketMix/ebijam25/internal/world"
+)
+
+// FeedMobs has every mob eat its upkeep. Mobs without enough food lose a schlub for every food they're short, from the back and leader last.
+func (t *Table) FeedMobs() {
+	// Starving can despawn mobs, so go over a copy.
+	for _, mob := range slices.Clone(t.Continent.Mobs) {
+		short := mob.Eat()
+		if short > 0 {
+			var starved []world.SchlubID
+			for i := len(mob.Schlubs) - 1; i >= 0 && len(starved) < short; i-- {
+				if mob.Schlubs[i].KindID() != int(world.SchlubKindPlayer) {
+					starved = append(starved, mob.Schlubs[i])
+				}
+			}
+			// The leader goes hungry last.
+			for _, schlub := range mob.Schlubs {
+				if len(starved) < short && schlub.KindID() == int(world.SchlubKindPlayer) {
+					starved = append(starved, schlub)
+				}
+			}
+			t.removeSchlubs(mob, starved)
+			t.DropItems(mob, starved)
+			if len(mob.Schlubs) == 0 {
+				t.DespawnMob(mob)
+				continue
+			}
+		}
+		t.SendMobFood(mob)
+	}
+}
+
+// SendMobFood tells the mob's owner how it's doing for food.
+func (t *Table) SendMobFood(mob *world.Mob) {
+	if player := t.GetPlayer(mob.OwnerID); player != nil {
+		player.bus.Publish(&event.MobFood{
+			ID:   mob.ID,
+			Food: mob.Food,
+		})
+	}
+}
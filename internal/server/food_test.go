@@ -0,0 +1,54 @@
+package server
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/ketMix/ebijam25/internal/world"
+)
+
+func TestFeedMobs(t *testing.T) {
+	table := emptyTable()
+	x, y := middle(table, 0)
+	ids := world.SchlubID(0).NextFamily().NextSchlubs(3)
+	leader := aged(ids[0], world.SchlubKindPlayer, 0, world.ItemNone)
+	first := aged(ids[1], world.SchlubKindVagrant, 10, world.ItemNone)
+	last := aged(ids[2], world.SchlubKindVagrant, 10, world.ItemBoots)
+
+	fed := table.Continent.NewMob(0, 1, x, y)
+	fed.AddSchlub(first)
+	fed.Food = 3
+	hungry := table.Continent.NewMob(0, 2, x, y)
+	hungry.AddSchlub(leader, first, last)
+	hungry.Food = 0
+	// A big mob a couple of food short loses a couple of schlubs off the back.
+	vagrants := world.SchlubID(0).NextFamily().NextFamily()
+	vagrants.SetKindID(int(world.SchlubKindVagrant))
+	crowd := vagrants.NextSchlubs(2*world.SchlubsPerFood + 1)
+	short := table.Continent.NewMob(0, 3, x, y)
+	short.AddSchlub(crowd...)
+	short.Food = 1
+
+	table.FeedMobs()
+	if fed.Food != 2 || len(fed.Schlubs) != 1 {
+		t.Errorf("fed mob has %d food and %d schlubs", fed.Food, len(fed.Schlubs))
+	}
+	if !slices.Equal(short.Schlubs, crowd[:len(crowd)-2]) || short.Food != 0 {
+		t.Errorf("short mob has %d schlubs and %d food left, want %d and none", len(short.Schlubs), short.Food, len(crowd)-2)
+	}
+	// The leader is up front, but the hungry starve from the back and the leader goes last.
+	if !slices.Equal(hungry.Schlubs, []world.SchlubID{leader, first}) {
+		t.Errorf("hungry mob is down to %v", hungry.Schlubs)
+	}
+	if len(table.Continent.Items) != 1 || table.Continent.Items[0].Item != world.ItemBoots {
+		t.Errorf("starving left %d items behind, want the boots", len(table.Continent.Items))
+	}
+	table.FeedMobs()
+	if !slices.Equal(hungry.Schlubs, []world.SchlubID{leader}) {
+		t.Errorf("hungry mob is down to %v, want just the leader", hungry.Schlubs)
+	}
+	table.FeedMobs()
+	if table.Continent.FindMob(hungry.ID) != nil {
+		t.Errorf("mob of nobody but a starving leader is still around")
+	}
+}
@@ -13,14 +13,19 @@ import (
 	"github.com/ketMix/ebijam25/internal/world"
 )
 
+// lobbyTimeout is how long a connection can sit in the lobby without saying anything.
+const lobbyTimeout = time.Minute * 5
+
 // Garçon governs getting clients to their game.
 type Garçon struct {
-	canceled chan bool
-	tables   Tables
+	canceled    chan bool
+	tables      Tables
+	ResumeGrace time.Duration // How long tables hold on to dropped players, DefaultResumeGrace if unset.
 }
 
 func (g *Garçon) Serve(port int, shouldGoroutine bool) {
 	g.canceled = make(chan bool, 1)
+	g.tables.resumeGrace = g.ResumeGrace
 	if shouldGoroutine {
 		go g.listen(port)
 	} else {
@@ -50,38 +55,120 @@ func (g *Garçon) listen(port int) {
 				return
 			}
 
-			ctx, cancel := context.WithTimeout(context.Background(), time.Second*60)
-			defer cancel()
+			g.host(c)
+		}),
+	)
+	if err != nil {
+		panic(fmt.Sprintf("failed to start server on port %d: %v", port, err))
+	}
+}
 
-			_, data, err := c.Read(ctx)
+// host keeps a connection in the lobby, answering its questions until it joins or resumes a table.
+func (g *Garçon) host(c *websocket.Conn) {
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), lobbyTimeout)
+		kind, data, err := c.Read(ctx)
+		cancel()
+		if err != nil {
+			fmt.Println("error reading from connection:", err)
+			c.Close(websocket.StatusInternalError, "failed to read initial message")
+			return
+		}
+		// Nothing's been negotiated yet, so answer in whatever they spoke.
+		codec := message.CodecFor(kind == websocket.MessageBinary)
+		msg, err := codec.Decode(data)
+		if err != nil {
+			fmt.Println("error decoding message:", err)
+			c.Close(websocket.StatusUnsupportedData, "failed to decode initial message")
+			return
+		}
+		switch msg := msg.(type) {
+		case *request.Lobby:
+			g.reply(c, codec, &event.MetaLobby{
+				Tables: g.tables.Lobby(),
+			})
+		case *request.CreateTable:
+			table, err := g.tables.CreateTable(msg.Name, msg.MaxPlayers, msg.Private)
 			if err != nil {
-				fmt.Println("error reading from connection:", err)
-				c.Close(websocket.StatusInternalError, "failed to read initial message")
+				g.reply(c, codec, &event.MetaLobby{
+					Tables: g.tables.Lobby(),
+					Error:  err.Error(),
+				})
+				continue
+			}
+			g.reply(c, codec, &event.MetaTableCreated{
+				ID:   table.ID,
+				Name: table.Name,
+				Code: table.Code,
+			})
+		case *request.Join:
+			if !message.CompatibleVersion(msg.Version) {
+				fmt.Println("rejecting client with protocol version", msg.Version)
+				c.Close(websocket.StatusPolicyViolation, fmt.Sprintf("incompatible protocol version %d, server speaks %d to %d, please update or refresh", msg.Version, message.MinProtocolVersion, message.ProtocolVersion))
 				return
 			}
-			msg, err := message.Decode(data)
+			// Let's get a table for 'em.
+			table, err := g.tables.FindTable(msg.Table, msg.Code, msg.Spectate)
 			if err != nil {
-				fmt.Println("error decoding message:", err)
+				// Let them pick another.
+				g.reply(c, codec, &event.MetaLobby{
+					Tables: g.tables.Lobby(),
+					Error:  err.Error(),
+				})
+				continue
+			}
+			player := world.NewPlayer(msg.Username, -1, msg.Color)
+			player.Spectator = msg.Spectate
+			if !g.tables.seatPlayer(table, &Player{
+				Player:   *player,
+				bus:      *event.NewBus("player-" + player.Username),
+				conn:     c,
+				codec:    message.Negotiate(msg.Codecs),
+				features: message.NegotiateFeatures(msg.Features),
+			}) {
+				c.Close(websocket.StatusTryAgainLater, "table closed")
+			}
+			return
+		case *request.Resume:
+			if !message.CompatibleVersion(msg.Version) {
+				fmt.Println("rejecting resume with protocol version", msg.Version)
+				c.Close(websocket.StatusPolicyViolation, fmt.Sprintf("incompatible protocol version %d, server speaks %d to %d, please update or refresh", msg.Version, message.MinProtocolVersion, message.ProtocolVersion))
 				return
 			}
-			if msg.Type() == "request-join" {
-				msg := msg.(*request.Join)
-				// Let's get a table for 'em.
-				table := g.tables.AcquireOpenTable()
-				if table == nil {
-					c.Close(websocket.StatusTryAgainLater, "no open tables")
-					return
-				}
-				player := world.NewPlayer(msg.Username, -1, msg.Color)
-				table.playerAdd <- &Player{
-					Player: *player,
-					bus:    *event.NewBus("player-" + player.Username),
-					conn:   c,
-				}
+			// The table sorts out whether the token is still any good.
+			table := g.tables.GetTable(tokenTable(msg.Token))
+			if table == nil {
+				c.Close(websocket.StatusPolicyViolation, "session expired, please rejoin")
+				return
 			}
-		}),
-	)
+			if !g.tables.resumePlayer(table, playerResume{
+				token: msg.Token,
+				conn:  c,
+			}) {
+				c.Close(websocket.StatusPolicyViolation, "session expired, please rejoin")
+			}
+			return
+		default:
+			c.Close(websocket.StatusPolicyViolation, "expected a lobby request, request-join or request-resume, got "+msg.Type())
+			return
+		}
+	}
+}
+
+// reply sends a message to a connection that's still in the lobby.
+func (g *Garçon) reply(c *websocket.Conn, codec message.Codec, msg message.MessageI) {
+	data, err := codec.Encode(msg)
 	if err != nil {
-		panic(fmt.Sprintf("failed to start server on port %d: %v", port, err))
+		fmt.Println("error encoding lobby message:", err)
+		return
+	}
+	kind := websocket.MessageText
+	if codec.Binary() {
+		kind = websocket.MessageBinary
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	if err := c.Write(ctx, kind, data); err != nil {
+		fmt.Println("error writing lobby message:", err)
 	}
 }
@@ -0,0 +1,95 @@
+package server
+
+import (
+	"slices"
+
+	"github.com/ketMix/ebijam25/internal/message/event"
+	"github.com/ketMix/ebijam25/internal/world"
+)
+
+// DropItem leaves an item on the ground. Players find out about it when it comes into view.
+func (t *Table) DropItem(item world.Item, x, y float64) *world.ItemDrop {
+	drop := &world.ItemDrop{
+		ID:   t.itemID.Next(),
+		Item: item,
+		X:    x,
+		Y:    y,
+	}
+	t.Continent.Items.Add(drop)
+	return drop
+}
+
+// DropItems drops whatever the schlubs were carrying around the mob, as they won't be needing it anymore.
+func (t *Table) DropItems(mob *world.Mob, schlubs []world.SchlubID) {
+	for _, schlub := range schlubs {
+		if schlub.Item() == world.ItemNone {
+			continue
+		}
+		spread := mob.Radius()
+		x := mob.X + (t.Continent.Fate.NumGen.Float64()*2-1)*spread
+		y := mob.Y + (t.Continent.Fate.NumGen.Float64()*2-1)*spread
+		t.DropItem(schlub.Item(), x, y)
+	}
+}
+
+// PickUpItems has schlubs in the mob with empty hands pick up any item drops the mob is standing on.
+func (t *Table) PickUpItems(mob *world.Mob) {
+	for _, drop := range t.Continent.Items.FindTouching(mob) {
+		i := slices.IndexFunc(mob.Schlubs, func(schlub world.SchlubID) bool {
+			return schlub.Item() == world.ItemNone && schlub.KindID() <= int(world.SchlubKindWarrior)
+		})
+		if i < 0 {
+			return // Everyone's hands are full.
+		}
+		schlub := mob.Schlubs[i]
+		mob.Schlubs[i].SetItemID(int(drop.Item))
+		t.Continent.Items.Remove(drop)
+		t.SendVisibleMobEvent(mob, &event.MobPickup{
+			ID:     mob.ID,
+			Drop:   drop.ID,
+			Schlub: int(schlub),
+			Item:   int(drop.Item),
+		})
+		t.log.Debug("item picked up", "mob", mob.ID, "item", drop.Item)
+	}
+}
+
+// RefreshVisibleItems sends ItemSpawn to players for item drops that are now visible and ItemDespawn for ones that are no longer visible or gone.
+func (t *Table) RefreshVisibleItems(player *Player) {
+	var visible world.ItemDrops
+	if player.Spectator && player.following == 0 {
+		visible = t.Continent.Items
+	} else {
+		viewer := player.ID
+		if player.Spectator {
+			viewer = player.following
+		}
+		for _, mob := range t.Continent.OwnedMobs(viewer) {
+			for _, drop := range t.Continent.Items.FindVisible(mob) {
+				if !slices.Contains(visible, drop) {
+					visible = append(visible, drop)
+				}
+			}
+		}
+	}
+	for _, drop := range visible {
+		if !slices.Contains(player.visibleItems, drop.ID) {
+			player.visibleItems = append(player.visibleItems, drop.ID)
+			player.bus.Publish(&event.ItemSpawn{
+				ID:   drop.ID,
+				Item: int(drop.Item),
+				X:    drop.X,
+				Y:    drop.Y,
+			})
+		}
+	}
+	player.visibleItems = slices.DeleteFunc(player.visibleItems, func(id world.ID) bool {
+		if slices.ContainsFunc(visible, func(drop *world.ItemDrop) bool { return drop.ID == id }) {
+			return false
+		}
+		player.bus.Publish(&event.ItemDespawn{
+			ID: id,
+		})
+		return true
+	})
+}
@@ -0,0 +1,45 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/ketMix/ebijam25/internal/world"
+)
+
+func TestDropAndPickUpItems(t *testing.T) {
+	table := emptyTable()
+	x, y := middle(table, 0)
+	ids := world.SchlubID(0).NextFamily().NextSchlubs(3)
+	spear := aged(ids[0], world.SchlubKindWarrior, 10, world.ItemSpear)
+	empty := aged(ids[1], world.SchlubKindWarrior, 10, world.ItemNone)
+	charm := aged(ids[2], world.SchlubKindMonk, 10, world.ItemCharm)
+	mob := table.Continent.NewMob(0, 1, x, y)
+	mob.AddSchlub(spear, empty, charm)
+
+	// Only what they were carrying is left behind, and it's left nearby.
+	table.DropItems(mob, []world.SchlubID{spear, empty, charm})
+	if len(table.Continent.Items) != 2 {
+		t.Fatalf("dropped %d items, want 2", len(table.Continent.Items))
+	}
+	for _, drop := range table.Continent.Items {
+		if drop.X < x-mob.Radius() || drop.X > x+mob.Radius() || drop.Y < y-mob.Radius() || drop.Y > y+mob.Radius() {
+			t.Errorf("%s dropped at %v, %v, away from the mob at %v, %v", drop.Item, drop.X, drop.Y, x, y)
+		}
+	}
+
+	// The one empty-handed warrior picks up one thing, the caravan can't carry anything.
+	picker := table.Continent.NewMob(0, 2, x, y)
+	var caravan world.SchlubID
+	caravan.SetKindID(int(world.SchlubKindCaravanWarrior))
+	picker.AddSchlub(caravan, empty)
+	table.PickUpItems(picker)
+	if len(table.Continent.Items) != 1 {
+		t.Errorf("%d items left on the ground, want 1", len(table.Continent.Items))
+	}
+	if picker.Schlubs[0].Item() != world.ItemNone {
+		t.Errorf("caravan picked up %s", picker.Schlubs[0].Item())
+	}
+	if got := picker.Schlubs[1].Item(); got != world.ItemSpear && got != world.ItemCharm {
+		t.Errorf("warrior picked up %s", got)
+	}
+}
@@ -0,0 +1,109 @@
+package server
+
+import (
+	"crypto/rand"
+	"errors"
+	"strings"
+
+	"github.com/ketMix/ebijam25/internal/message/event"
+	"github.com/ketMix/ebijam25/internal/world"
+)
+
+const (
+	MaxTables        = 64 // Max tables running at once, so nobody can spin up the whole server.
+	MaxTablePlayers  = 32 // Max players a created table may ask for.
+	MaxTableNameLen  = 32
+	joinCodeLen      = 6
+	joinCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789" // No 0/O or 1/I, they look too much alike.
+)
+
+var (
+	ErrTableNotFound = errors.New("no such table")
+	ErrTablePrivate  = errors.New("that table is private, you need its join code")
+	ErrTableFull     = errors.New("that table is full")
+	ErrTooManyTables = errors.New("too many tables, try joining one instead")
+)
+
+// Lobby returns the public tables that are still running.
+func (t *Tables) Lobby() []event.TableInfo {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var infos []event.TableInfo
+	for _, table := range t.tables {
+		if table.Code != "" {
+			continue
+		}
+		infos = append(infos, event.TableInfo{
+			ID:         table.ID,
+			Name:       table.Name,
+			Players:    int(table.seated.Load()),
+			Spectators: int(table.watching.Load()),
+			MaxPlayers: table.MaxPlayers,
+		})
+	}
+	return infos
+}
+
+// CreateTable creates a new table with the given settings. Private tables get a join code and are left out of the lobby.
+func (t *Tables) CreateTable(name string, maxPlayers int, private bool) (*Table, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.tables) >= MaxTables {
+		return nil, ErrTooManyTables
+	}
+
+	name = strings.TrimSpace(name)
+	if runes := []rune(name); len(runes) > MaxTableNameLen {
+		name = string(runes[:MaxTableNameLen])
+	}
+	var code string
+	if private {
+		code = t.newJoinCode()
+	}
+	return t.newTable(name, min(maxPlayers, MaxTablePlayers), code), nil
+}
+
+// FindTable returns the table a join request should go to. A code finds its table by itself, an ID finds that table, and neither finds any open public table. Spectators don't need a free seat.
+func (t *Tables) FindTable(id world.ID, code string, spectate bool) (*Table, error) {
+	if id == 0 && code == "" {
+		return t.AcquireOpenTable(), nil
+	}
+
+	code = strings.ToUpper(strings.TrimSpace(code))
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, table := range t.tables {
+		if (code != "" && table.Code == code) || (code == "" && table.ID == id) {
+			if table.Code != code {
+				return nil, ErrTablePrivate
+			}
+			if !spectate && !table.open.Load() {
+				return nil, ErrTableFull
+			}
+			return table, nil
+		}
+	}
+	return nil, ErrTableNotFound
+}
+
+// newJoinCode makes a join code no other table is using. The caller must hold t.mu.
+func (t *Tables) newJoinCode() string {
+	for {
+		b := make([]byte, joinCodeLen)
+		rand.Read(b)
+		for i := range b {
+			b[i] = joinCodeAlphabet[int(b[i])%len(joinCodeAlphabet)]
+		}
+		code := string(b)
+		taken := false
+		for _, table := range t.tables {
+			if table.Code == code {
+				taken = true
+				break
+			}
+		}
+		if !taken {
+			return code
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package server
 
 import (
+	"math"
 	"slices"
 
 	"github.com/ketMix/ebijam25/internal/message/event"
@@ -12,31 +13,120 @@ func (t *Table) UpdateMob(mob *world.Mob) {
 	mob.Update(&t.State)
 }
 
+// SplitMob moves the given schlubs out of the mob and into a new mob owned by the same player. The new mob is placed just outside of the source mob so the two don't immediately collide.
+func (t *Table) SplitMob(mob *world.Mob, schlubs []world.SchlubID) *world.Mob {
+	// Food gets shared out by headcount.
+	food := mob.Food * len(schlubs) / max(1, len(mob.Schlubs))
+	mob.Food -= food
+	mob.RemoveSchlub(schlubs...)
+
+	// Pop it out at a random angle, far enough away to not be touching.
+	angle := t.Continent.Fate.NumGen.Float64() * 2 * math.Pi
+	distance := mob.Radius() + (&world.Mob{Schlubs: schlubs}).Radius() + 1
+	x, y := world.ClampToContinent(mob.X+math.Cos(angle)*distance, mob.Y+math.Sin(angle)*distance)
+
+	split := t.Continent.NewMob(mob.OwnerID, t.mobID.Next(), x, y)
+	split.OuterKind = mob.OuterKind
+	split.AddSchlub(schlubs...)
+	split.Food = food
+	return split
+}
+
+// MergeMob folds the from mob's schlubs into the to mob and removes the from mob from the continent. Players are told about the merge based on which of the two mobs they could see.
+func (t *Table) MergeMob(from, to *world.Mob) {
+	schlubs := from.Schlubs
+	to.AddSchlub(schlubs...)
+	to.StoreFood(from.Food)
+	from.Schlubs = nil
+	t.Continent.RemoveMob(from)
+
+	// Stop chasing a mob that no longer exists.
+	if to.TargetID == from.ID {
+		to.TargetID = 0
+		to.TargetX = to.X
+		to.TargetY = to.Y
+	}
+
+	var schlubIDs []int
+	for _, s := range schlubs {
+		schlubIDs = append(schlubIDs, int(s))
+	}
+
+	for _, player := range t.players {
+		seesFrom := slices.Contains(player.VisibleMobIDs, from.ID)
+		seesTo := slices.Contains(player.VisibleMobIDs, to.ID)
+		if seesFrom {
+			player.VisibleMobIDs = slices.DeleteFunc(player.VisibleMobIDs, func(id world.ID) bool {
+				return id == from.ID
+			})
+		}
+		if seesFrom && seesTo {
+			player.bus.Publish(&event.MobMerge{
+				From: from.ID,
+				To:   to.ID,
+			})
+		} else if seesFrom {
+			t.HideMobFrom(player, from)
+		} else if seesTo {
+			player.bus.Publish(&event.MobCreate{
+				ID:  to.ID,
+				IDs: schlubIDs,
+			})
+		}
+	}
+	t.log.Debug("mob merged", "from", from.ID, "to", to.ID, "schlubs", len(to.Schlubs))
+}
+
 // RefreshVisibleMobs sends MobSpawn to players for mobs that are now visible and MobDespawn for mobs that are no longer visible.
 func (t *Table) RefreshVisibleMobs(player *Player) {
 	if player == nil {
 		return
 	}
-	if mob := t.Continent.Mobs.FindByID(player.MobID); mob != nil {
-		visibleMobs := t.Continent.Mobs.FindVisible(player.MobID)
-		for _, visibleMob := range visibleMobs {
-			if !slices.Contains(player.VisibleMobIDs, visibleMob.ID) {
-				player.VisibleMobIDs = append(player.VisibleMobIDs, visibleMob.ID)
-				// Send the new visible mob to the player
-				t.log.Debug("new visible mob", "player", player.MobID, "mob", visibleMob.ID)
-				t.SendMobTo(visibleMob, player)
-			}
+	var visibleMobs world.Mobs
+	if player.Spectator && player.following == 0 {
+		// Spectators not following anyone get to see everything.
+		visibleMobs = t.Continent.Mobs
+	} else {
+		// A player sees whatever any of their mobs can see, and a spectator sees whatever the player they follow sees.
+		viewer := player.ID
+		if player.Spectator {
+			viewer = player.following
+		}
+		owned := t.Continent.OwnedMobs(viewer)
+		if len(owned) == 0 && !player.Spectator {
+			return
 		}
-		// Check for mobs that are no longer visible
-		for i := len(player.VisibleMobIDs) - 1; i >= 0; i-- {
-			if !slices.Contains(visibleMobs, t.Continent.Mobs.FindByID(player.VisibleMobIDs[i])) {
-				t.HideMobFrom(player, t.Continent.Mobs.FindByID(player.VisibleMobIDs[i]))
-				// Notify the player about the mob that is no longer visible
-				t.log.Debug("mob no longer visible", "player", player.MobID, "mob", player.VisibleMobIDs[i])
-				player.VisibleMobIDs = append(player.VisibleMobIDs[:i], player.VisibleMobIDs[i+1:]...)
+		seen := make(map[world.ID]bool)
+		for _, mob := range owned {
+			for _, visibleMob := range t.Continent.VisibleMobs(mob) {
+				if !seen[visibleMob.ID] {
+					seen[visibleMob.ID] = true
+					visibleMobs = append(visibleMobs, visibleMob)
+				}
 			}
 		}
 	}
+	visible := make(map[world.ID]bool, len(visibleMobs))
+	for _, visibleMob := range visibleMobs {
+		visible[visibleMob.ID] = true
+	}
+	for _, visibleMob := range visibleMobs {
+		if !slices.Contains(player.VisibleMobIDs, visibleMob.ID) {
+			player.VisibleMobIDs = append(player.VisibleMobIDs, visibleMob.ID)
+			// Send the new visible mob to the player
+			t.log.Debug("new visible mob", "player", player.MobID, "mob", visibleMob.ID)
+			t.SendMobTo(visibleMob, player)
+		}
+	}
+	// Check for mobs that are no longer visible
+	for i := len(player.VisibleMobIDs) - 1; i >= 0; i-- {
+		if !visible[player.VisibleMobIDs[i]] {
+			t.HideMobFrom(player, t.Continent.FindMob(player.VisibleMobIDs[i]))
+			// Notify the player about the mob that is no longer visible
+			t.log.Debug("mob no longer visible", "player", player.MobID, "mob", player.VisibleMobIDs[i])
+			player.VisibleMobIDs = append(player.VisibleMobIDs[:i], player.VisibleMobIDs[i+1:]...)
+		}
+	}
 }
 
 // HideMobFrom hides a mob from a player, so they can't see it anymore.
@@ -85,3 +175,15 @@ func (t *Table) SendVisibleMobEvent(mob *world.Mob, evt event.Event) {
 		player.bus.Publish(evt)
 	}
 }
+
+// SendVisibleMobsEvent sends the event once to every player that can see any of the mobs.
+func (t *Table) SendVisibleMobsEvent(evt event.Event, mobs ...*world.Mob) {
+	for _, player := range t.players {
+		if !slices.ContainsFunc(mobs, func(mob *world.Mob) bool {
+			return slices.Contains(player.VisibleMobIDs, mob.ID)
+		}) {
+			continue
+		}
+		player.bus.Publish(evt)
+	}
+}
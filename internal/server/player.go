@@ -2,20 +2,87 @@ package server
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/coder/websocket"
 	"github.com/ketMix/ebijam25/internal/message"
 	"github.com/ketMix/ebijam25/internal/message/event"
+	"github.com/ketMix/ebijam25/internal/progression"
 	"github.com/ketMix/ebijam25/internal/world"
 )
 
 // Player represents a player in the game gstance. It can be AI or a real hummus.
 type Player struct {
-	world.Player // Just embed that shiz
-	bus          event.Bus
-	conn         *websocket.Conn
-	cancel       context.CancelFunc
-	lastRefresh  int
+	world.Player       // Just embed that shiz
+	bus                event.Bus
+	conn               *websocket.Conn
+	cancel             context.CancelFunc
+	codec              message.Codec // Codec negotiated on join, used for everything we send them.
+	features           []string      // Features negotiated on join.
+	token              string        // Token the player can resume their session with.
+	dropped            bool          // Whether the player's connection dropped and we're waiting for them to resume.
+	droppedAt          time.Time
+	lastRefresh        int
+	pingSeq            int           // Sequence number of the last ping sent.
+	pingSent           time.Time     // When the last ping was sent.
+	rtt                time.Duration // Last measured round-trip time.
+	following          world.ID      // Player whose vision a spectator sees, 0 for everything.
+	tech               progression.TechState
+	techUsed           map[string]time.Time // When each usable skill was last used.
+	visibleItems       []world.ID           // Item drops the player has been sent.
+	visibleResources   []world.ID           // Resources the player has been sent.
+	visibleSettlements []world.ID           // Settlements the player has been sent.
+}
+
+// Send encodes the message with the player's negotiated codec and writes it to their connection.
+func (p *Player) Send(ctx context.Context, msg message.MessageI) error {
+	codec := p.codec
+	if codec == nil {
+		codec = message.JSONCodec
+	}
+	data, err := codec.Encode(msg)
+	if err != nil {
+		return err
+	}
+	kind := websocket.MessageText
+	if codec.Binary() {
+		kind = websocket.MessageBinary
+	}
+	return p.conn.Write(ctx, kind, data)
+}
+
+// HasFeature returns true if the feature was negotiated with the player.
+func (p *Player) HasFeature(feature string) bool {
+	return slices.Contains(p.features, feature)
+}
+
+// Ping sends the player a new ping, along with the last round-trip time we measured for them.
+func (p *Player) Ping(ctx context.Context) error {
+	p.pingSeq++
+	p.pingSent = time.Now()
+	return p.Send(ctx, &event.MetaPing{
+		Seq: p.pingSeq,
+		RTT: int(p.rtt.Milliseconds()),
+	})
+}
+
+// Pong records the round-trip time if seq answers the latest ping. Answers to older pings are ignored.
+func (p *Player) Pong(seq int) {
+	if seq != p.pingSeq {
+		return
+	}
+	p.rtt = time.Since(p.pingSent)
+}
+
+// RTT returns the last measured round-trip time to the player. It is 0 until their first pong arrives.
+func (p *Player) RTT() time.Duration {
+	return p.rtt
 }
 
 // PlayerMessage is a wrapper around messages to attach a player to it. This is used to ensure that messages received by a connection are mapped to their appropriate player.
@@ -28,3 +95,35 @@ type PlayerMessage struct {
 func (m PlayerMessage) Type() string {
 	return "player-message"
 }
+
+// playerConn pairs a player with a specific connection, so a stale connection can be told apart from the one the player is using now.
+type playerConn struct {
+	player *Player
+	conn   *websocket.Conn
+}
+
+// playerResume asks a table to reattach a new connection to whichever player holds the token.
+type playerResume struct {
+	token string
+	conn  *websocket.Conn
+}
+
+// newToken makes a resume token. It is prefixed with the table ID so Garçon knows where to send whoever presents it.
+func newToken(tableID world.ID) string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return fmt.Sprintf("%d.%s", tableID, hex.EncodeToString(b))
+}
+
+// tokenTable returns the ID of the table a resume token belongs to, or 0 if the token is garbage.
+func tokenTable(token string) world.ID {
+	prefix, _, found := strings.Cut(token, ".")
+	if !found {
+		return 0
+	}
+	id, err := strconv.Atoi(prefix)
+	if err != nil {
+		return 0
+	}
+	return id
+}
@@ -0,0 +1,138 @@
+package server
+
+import (
+	"slices"
+
+	"github.com/ketMix/ebijam25/internal/message/event"
+	"github.com/ketMix/ebijam25/internal/world"
+)
+
+const (
+	MaxResources     = 200 // Resources stop growing once this many are around.
+	ResourceMinFood  = 10
+	ResourceMaxFood  = 30
+	resourceAttempts = 10 // Random tiles tried before giving up on finding fertile ground.
+)
+
+// AddResource grows a resource on a random fertile tile, if it can find one.
+func (d *Director) AddResource() {
+	t := d.table
+	if t.Continent.ResourceCount() >= MaxResources {
+		return
+	}
+	numGen := t.Continent.Fate.NumGen
+	for range resourceAttempts {
+		fief := t.Continent.Fiefs[numGen.Intn(len(t.Continent.Fiefs))]
+		i := numGen.Intn(len(fief.Tiles))
+		if !fief.Tiles[i].Terrain.Fertile() {
+			continue
+		}
+		x, y := fief.TilePosition(i)
+		res := &world.Resource{
+			ID:   t.resourceID.Next(),
+			X:    x + numGen.Float64()*world.TileSize,
+			Y:    y + numGen.Float64()*world.TileSize,
+			Food: ResourceMinFood + numGen.Intn(ResourceMaxFood-ResourceMinFood+1),
+		}
+		fief.Resources.Add(res)
+		t.log.Debug("added resource", "id", res.ID, "x", res.X, "y", res.Y, "food", res.Food)
+		return
+	}
+}
+
+// ForageAmount returns how much food the mob can gather from a resource in one go. Bigger mobs have more hands.
+func ForageAmount(mob *world.Mob) int {
+	return 1 + len(mob.Schlubs)/5
+}
+
+// Forage has every mob gather food from whatever resources it's standing on, as much as it can carry. Resources that run out are removed.
+func (t *Table) Forage() {
+	for _, fief := range t.Continent.Fiefs {
+		if len(fief.Resources) == 0 {
+			continue
+		}
+		// Resources that run out get removed, so go over a copy.
+		for _, res := range slices.Clone(fief.Resources) {
+			for _, mob := range t.Continent.MobsNear(res.X, res.Y, res.Radius()) {
+				if res.Food == 0 {
+					break
+				}
+				room := mob.FoodCapacity() - mob.Food
+				if room <= 0 {
+					continue
+				}
+				mob.StoreFood(t.DepleteResource(fief, res, min(ForageAmount(mob), room)))
+				t.SendMobFood(mob)
+			}
+		}
+	}
+}
+
+// DepleteResource takes up to amount food from the resource and tells whoever can see it, removing it if it runs out. It returns how much was actually taken.
+func (t *Table) DepleteResource(fief *world.Fief, res *world.Resource, amount int) int {
+	amount = res.Deplete(amount)
+	if res.Food == 0 {
+		// Players still seeing it get told on their next visibility refresh.
+		fief.Resources.Remove(res)
+		return amount
+	}
+	for _, player := range t.players {
+		if slices.Contains(player.visibleResources, res.ID) {
+			player.bus.Publish(&event.ResourceUpdate{
+				ID:   res.ID,
+				Food: res.Food,
+			})
+		}
+	}
+	return amount
+}
+
+// RefreshVisibleResources sends ResourceSpawn to players for resources that are now visible and ResourceDespawn for ones that are no longer visible or eaten up.
+func (t *Table) RefreshVisibleResources(player *Player) {
+	var viewers world.Mobs
+	if !player.Spectator || player.following != 0 {
+		viewer := player.ID
+		if player.Spectator {
+			viewer = player.following
+		}
+		viewers = t.Continent.OwnedMobs(viewer)
+	}
+	var visible []world.ID
+	for i, fief := range t.Continent.Fiefs {
+		var found world.Resources
+		if player.Spectator && player.following == 0 {
+			// Spectators not following anyone get to see everything.
+			found = fief.Resources
+		} else {
+			for _, mob := range viewers {
+				for _, res := range fief.Resources.FindVisible(mob) {
+					if !slices.Contains(found, res) {
+						found = append(found, res)
+					}
+				}
+			}
+		}
+		for _, res := range found {
+			visible = append(visible, res.ID)
+			if !slices.Contains(player.visibleResources, res.ID) {
+				player.visibleResources = append(player.visibleResources, res.ID)
+				player.bus.Publish(&event.ResourceSpawn{
+					ID:   res.ID,
+					Fief: i,
+					X:    res.X,
+					Y:    res.Y,
+					Food: res.Food,
+				})
+			}
+		}
+	}
+	player.visibleResources = slices.DeleteFunc(player.visibleResources, func(id world.ID) bool {
+		if slices.Contains(visible, id) {
+			return false
+		}
+		player.bus.Publish(&event.ResourceDespawn{
+			ID: id,
+		})
+		return true
+	})
+}
@@ -0,0 +1,69 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/ketMix/ebijam25/internal/world"
+)
+
+func TestAddResource(t *testing.T) {
+	table := emptyTable()
+	table.Continent.ClearResources()
+	for range 10 * MaxResources {
+		table.director.AddResource()
+	}
+	if got := table.Continent.ResourceCount(); got != MaxResources {
+		t.Fatalf("grew %d resources, want %d", got, MaxResources)
+	}
+	for _, fief := range table.Continent.Fiefs {
+		for _, res := range fief.Resources {
+			if !table.Continent.TerrainAt(res.X, res.Y).Fertile() {
+				t.Errorf("resource %d grew on %s", res.ID, table.Continent.TerrainAt(res.X, res.Y))
+			}
+			if res.Food < ResourceMinFood || res.Food > ResourceMaxFood {
+				t.Errorf("resource %d has %d food", res.ID, res.Food)
+			}
+			if table.Continent.GetContainingFief(res.X, res.Y) != fief {
+				t.Errorf("resource %d isn't in its fief", res.ID)
+			}
+		}
+	}
+}
+
+func TestForage(t *testing.T) {
+	table := emptyTable()
+	table.Continent.ClearResources()
+	x, y := middle(table, 0)
+	fief := table.Continent.Fiefs[0]
+	res := &world.Resource{ID: 1, X: x, Y: y, Food: 12}
+	fief.Resources.Add(res)
+
+	vagrants := world.SchlubID(0).NextFamily()
+	vagrants.SetKindID(int(world.SchlubKindVagrant))
+	mob := table.Continent.NewMob(0, 1, x, y)
+	mob.AddSchlub(vagrants.NextSchlubs(10)...)
+	mob.Food = mob.FoodCapacity() - 1
+	full := table.Continent.NewMob(0, 2, x, y)
+	full.Food = full.FoodCapacity()
+
+	// Only as much as there's room for, and nothing for those already full.
+	table.Forage()
+	if mob.Food != mob.FoodCapacity() || res.Food != 11 {
+		t.Errorf("forager has %d of %d food, resource %d left", mob.Food, mob.FoodCapacity(), res.Food)
+	}
+	if full.Food != full.FoodCapacity() {
+		t.Errorf("full mob has %d of %d food", full.Food, full.FoodCapacity())
+	}
+
+	// Eaten up resources go away.
+	mob.Food = 0
+	for range 10 {
+		table.Forage()
+	}
+	if fief.Resources.FindByID(res.ID) != nil || res.Food != 0 {
+		t.Errorf("resource with %d food is still around", res.Food)
+	}
+	if mob.Food != 11 {
+		t.Errorf("forager has %d food, want all 11 that were left", mob.Food)
+	}
+}
@@ -0,0 +1,175 @@
+package server
+
+import (
+	"slices"
+
+	"github.com/ketMix/ebijam25/internal/message/event"
+	"github.com/ketMix/ebijam25/internal/progression"
+	"github.com/ketMix/ebijam25/internal/world"
+)
+
+// FoundSettlement has the player's mob give up schlubs to found a settlement where it stands. Each fief only has room for one settlement.
+func (t *Table) FoundSettlement(player *Player) {
+	if !player.tech.CanConstruct(t.techs, progression.ConstructSettlement) {
+		t.log.Warn("settlement request received but settlements are locked", "player", player.ID)
+		return
+	}
+	mob := t.Continent.FindMob(player.MobID)
+	if mob == nil || mob.OwnerID != player.ID {
+		t.log.Warn("settlement request received but player has no mob", "player", player.ID)
+		return
+	}
+	fief := t.Continent.FiefIndex(mob.X, mob.Y)
+	if fief < 0 {
+		return
+	}
+	if existing := t.Continent.Settlements.FindByFief(fief); existing != nil {
+		t.log.Debug("settlement request received but fief is already settled", "player", player.ID, "fief", fief, "settlement", existing.ID)
+		return
+	}
+	// Settlers are the schlubs at the back, the leader stays with the mob.
+	if !t.SacrificeSchlubs(mob, world.SettlementCost) {
+		t.log.Debug("settlement request received but player can't afford it", "player", player.ID, "cost", world.SettlementCost)
+		return
+	}
+	settlement := &world.Settlement{
+		ID:      t.settlementID.Next(),
+		OwnerID: player.ID,
+		Fief:    fief,
+		X:       mob.X,
+		Y:       mob.Y,
+		Health:  world.SettlementCost,
+	}
+	t.Continent.Settlements.Add(settlement)
+	t.log.Info("settlement founded", "player", player.ID, "id", settlement.ID, "fief", fief)
+}
+
+// UpdateSettlements runs a round for every settlement. Settlements are besieged by any other mob bumping into them, and every so often raise a schlub for the nearest of their owner's mobs if their fief has the food for it.
+func (t *Table) UpdateSettlements() {
+	for _, settlement := range slices.Clone(t.Continent.Settlements) {
+		changed := t.siegeSettlement(settlement)
+		if settlement.Health > 0 {
+			changed = t.produceSettlement(settlement) || changed
+		}
+		if settlement.Health <= 0 || settlement.Abandoned() {
+			// Players still seeing it get told on their next visibility refresh.
+			t.Continent.Settlements.Remove(settlement)
+			t.log.Info("settlement lost", "id", settlement.ID, "owner", settlement.OwnerID, "health", settlement.Health, "failures", settlement.Failures)
+			continue
+		}
+		if changed {
+			for _, player := range t.players {
+				if slices.Contains(player.visibleSettlements, settlement.ID) {
+					player.bus.Publish(&event.SettlementUpdate{
+						ID:       settlement.ID,
+						Health:   settlement.Health,
+						Failures: settlement.Failures,
+					})
+				}
+			}
+		}
+	}
+}
+
+// siegeSettlement has every mob not owned by the settlement's owner that's bumping into it do some damage. It returns true if the settlement took any.
+func (t *Table) siegeSettlement(settlement *world.Settlement) bool {
+	damage := 0
+	for _, mob := range t.Continent.MobsNear(settlement.X, settlement.Y, world.SettlementRadius) {
+		if mob.OwnerID != settlement.OwnerID {
+			damage += mob.SiegeDamage()
+		}
+	}
+	settlement.Health -= damage
+	return damage > 0
+}
+
+// produceSettlement raises a schlub for the nearest of the owner's mobs in reach, paid for with food from the settlement's fief. It returns true if the settlement's failures changed.
+func (t *Table) produceSettlement(settlement *world.Settlement) bool {
+	settlement.Rounds++
+	if settlement.Rounds < world.SettlementRounds {
+		return false
+	}
+	fief := t.Continent.Fiefs[settlement.Fief]
+	food := 0
+	for _, res := range fief.Resources {
+		food += res.Food
+	}
+	if food < world.SettlementFood {
+		settlement.Rounds = 0
+		settlement.Failures++
+		return true
+	}
+
+	var nearest *world.Mob
+	for _, mob := range t.Continent.OwnedMobs(settlement.OwnerID) {
+		if len(mob.Schlubs) >= world.MaxSchlubsPerMob || !settlement.Reaches(mob) {
+			continue
+		}
+		if nearest == nil || settlement.Distance(mob) < settlement.Distance(nearest) {
+			nearest = mob
+		}
+	}
+	if nearest == nil {
+		// Nobody around to take them, so wait for someone to turn up.
+		return false
+	}
+
+	food = world.SettlementFood
+	for _, res := range slices.Clone(fief.Resources) {
+		if food == 0 {
+			break
+		}
+		food -= t.DepleteResource(fief, res, food)
+	}
+	t.RecruitSchlubs(nearest, world.SchlubKindVagrant, 1)
+	settlement.Rounds = 0
+	if settlement.Failures == 0 {
+		return false
+	}
+	settlement.Failures = 0
+	return true
+}
+
+// RefreshVisibleSettlements sends SettlementSpawn to players for settlements that are now visible and SettlementDespawn for ones that are no longer visible or gone. Players can always see their own settlements.
+func (t *Table) RefreshVisibleSettlements(player *Player) {
+	var visible world.Settlements
+	if player.Spectator && player.following == 0 {
+		visible = t.Continent.Settlements
+	} else {
+		viewer := player.ID
+		if player.Spectator {
+			viewer = player.following
+		}
+		visible = t.Continent.Settlements.FindByOwner(viewer)
+		for _, mob := range t.Continent.OwnedMobs(viewer) {
+			for _, settlement := range t.Continent.Settlements.FindVisible(mob) {
+				if !slices.Contains(visible, settlement) {
+					visible = append(visible, settlement)
+				}
+			}
+		}
+	}
+	for _, settlement := range visible {
+		if !slices.Contains(player.visibleSettlements, settlement.ID) {
+			player.visibleSettlements = append(player.visibleSettlements, settlement.ID)
+			player.bus.Publish(&event.SettlementSpawn{
+				ID:       settlement.ID,
+				Owner:    settlement.OwnerID,
+				Fief:     settlement.Fief,
+				X:        settlement.X,
+				Y:        settlement.Y,
+				Health:   settlement.Health,
+				Failures: settlement.Failures,
+			})
+		}
+	}
+	player.visibleSettlements = slices.DeleteFunc(player.visibleSettlements, func(id world.ID) bool {
+		if slices.ContainsFunc(visible, func(settlement *world.Settlement) bool { return settlement.ID == id }) {
+			return false
+		}
+		player.bus.Publish(&event.SettlementDespawn{
+			ID: id,
+		})
+		return true
+	})
+}
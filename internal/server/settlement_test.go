@@ -0,0 +1,118 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/ketMix/ebijam25/internal/world"
+)
+
+// settler returns a player of the given ID, knowing the given skills, with a mob of count vagrants in the middle of the fief.
+func settler(table *Table, id world.ID, fief, count int, skills ...string) (*Player, *world.Mob) {
+	player := &Player{Player: world.Player{ID: id, MobID: id}}
+	for _, skill := range skills {
+		player.tech.Add(skill)
+	}
+	x, y := middle(table, fief)
+	mob := table.Continent.NewMob(id, id, x, y)
+	vagrants := world.SchlubID(0).NextFamily()
+	vagrants.SetKindID(int(world.SchlubKindVagrant))
+	mob.AddSchlub(vagrants.NextSchlubs(count)...)
+	return player, mob
+}
+
+func TestFoundSettlement(t *testing.T) {
+	table := emptyTable()
+	player, mob := settler(table, 1, 0, world.SettlementCost+2, "wandering", "settle")
+	table.FoundSettlement(player)
+	settlement := table.Continent.Settlements.FindByFief(0)
+	if settlement == nil || settlement.OwnerID != 1 || settlement.Health != world.SettlementCost {
+		t.Fatalf("founded %+v", settlement)
+	}
+	if len(mob.Schlubs) != 2 {
+		t.Errorf("mob has %d schlubs after settling, want 2", len(mob.Schlubs))
+	}
+
+	// One to a fief.
+	mob.AddSchlub(world.SchlubID(0).NextFamily().NextFamily().NextSchlubs(world.SettlementCost)...)
+	table.FoundSettlement(player)
+	if len(table.Continent.Settlements) != 1 || len(mob.Schlubs) != world.SettlementCost+2 {
+		t.Errorf("settled the same fief twice")
+	}
+
+	// Only with enough settlers, and only with the know-how.
+	poor, _ := settler(table, 2, 1, world.SettlementCost-1, "wandering", "settle")
+	table.FoundSettlement(poor)
+	novice, _ := settler(table, 3, 2, world.SettlementCost+1, "wandering")
+	table.FoundSettlement(novice)
+	if len(table.Continent.Settlements) != 1 {
+		t.Errorf("%d settlements founded, want 1", len(table.Continent.Settlements))
+	}
+}
+
+// settle returns a settlement for the owner in the middle of the fief with food for the given number of schlubs in it.
+func settle(table *Table, owner world.ID, fief, raise int) *world.Settlement {
+	x, y := middle(table, fief)
+	settlement := &world.Settlement{ID: owner, OwnerID: owner, Fief: fief, X: x, Y: y, Health: world.SettlementCost}
+	table.Continent.Settlements.Add(settlement)
+	if raise > 0 {
+		table.Continent.Fiefs[fief].Resources.Add(&world.Resource{ID: owner, X: x, Y: y, Food: raise * world.SettlementFood})
+	}
+	return settlement
+}
+
+func TestSettlementRaisesSchlubs(t *testing.T) {
+	table := emptyTable()
+	table.Continent.ClearResources()
+	settle(table, 1, 0, 2)
+	_, mob := settler(table, 1, 0, 1)
+
+	for round := range 3 * world.SettlementRounds {
+		table.UpdateSettlements()
+		if want := 1 + min(2, (round+1)/world.SettlementRounds); len(mob.Schlubs) != want {
+			t.Fatalf("mob has %d schlubs on round %d, want %d", len(mob.Schlubs), round, want)
+		}
+	}
+	if got := table.Continent.ResourceCount(); got != 0 {
+		t.Errorf("%d resources left after raising schlubs with all their food", got)
+	}
+}
+
+func TestSettlementStarves(t *testing.T) {
+	table := emptyTable()
+	table.Continent.ClearResources()
+	settlement := settle(table, 1, 0, 0)
+	for range (world.SettlementMaxFailures + 1) * world.SettlementRounds {
+		if table.Continent.Settlements.FindByID(settlement.ID) == nil {
+			t.Fatalf("abandoned after %d failures", settlement.Failures)
+		}
+		table.UpdateSettlements()
+	}
+	if !settlement.Abandoned() || table.Continent.Settlements.FindByID(settlement.ID) != nil {
+		t.Errorf("settlement with %d failures is still around", settlement.Failures)
+	}
+}
+
+func TestSiegeSettlement(t *testing.T) {
+	table := emptyTable()
+	table.Continent.ClearResources()
+	settlement := settle(table, 1, 0, 0)
+	// The owner's own mob doesn't besiege it.
+	settler(table, 1, 0, 50)
+	table.UpdateSettlements()
+	if settlement.Health != world.SettlementCost {
+		t.Fatalf("owner did %d damage", world.SettlementCost-settlement.Health)
+	}
+	_, raiders := settler(table, 2, 0, 50)
+	for range world.SettlementCost {
+		table.UpdateSettlements()
+		if table.Continent.Settlements.FindByID(settlement.ID) == nil {
+			break
+		}
+		if settlement.Health > world.SettlementCost-raiders.SiegeDamage() {
+			t.Fatalf("raiders did %d damage, want %d", world.SettlementCost-settlement.Health, raiders.SiegeDamage())
+		}
+	}
+	if settlement.Health > 0 || table.Continent.Settlements.FindByID(settlement.ID) != nil {
+		t.Errorf("settlement held out with %d health", settlement.Health)
+	}
+}
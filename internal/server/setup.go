@@ -3,9 +3,11 @@ package server
 import (
 	"fmt"
 	"math/rand/v2"
+	"slices"
 
 	"github.com/ketMix/ebijam25/internal/message/event"
 	"github.com/ketMix/ebijam25/internal/message/request"
+	"github.com/ketMix/ebijam25/internal/progression"
 	"github.com/ketMix/ebijam25/internal/world"
 )
 
@@ -14,122 +16,67 @@ func (t *Table) Setup() {
 	t.Seed = rand.Uint()
 	t.State.Continent = world.NewContinent(t.Seed) // Create a new continent with the seed and dimensions
 	t.EventBus = *event.NewBus("table-" + fmt.Sprintf("%d", t.ID))
+	techs, err := progression.LoadTechTree()
+	if err != nil {
+		panic("failed to load tech tree: " + err.Error())
+	}
+	if err := techs.CheckUsable(func(skill string) bool {
+		_, ok := techEffects[skill]
+		return ok
+	}); err != nil {
+		panic(err.Error())
+	}
+	t.techs = techs
 	t.EventBus.Subscribe((event.MobPosition{}).Type(), func(e event.Event) {
 		evt := e.(*event.MobPosition)
-		if mob := t.Continent.Mobs.FindByID(evt.ID); mob != nil {
+		if mob := t.Continent.FindMob(evt.ID); mob != nil {
 			t.Continent.MoveMob(mob, evt.X, evt.Y)
 			// For now just send it, I guess.
 			t.SendVisibleMobEvent(mob, e)
+			t.PickUpItems(mob)
 
 			// Check if we're intersecting with any other mobs.
-			for _, other := range t.State.Continent.Mobs {
-				if other.ID != mob.ID && mob.Intersects(other) {
-					var baseDamage int
-					switch mob.OuterKind {
-					case world.SchlubKindPlayer:
-						baseDamage = -10
-					case world.SchlubKindMonk:
-						baseDamage = -1 // Just overload -1 to mean conversion.
-					case world.SchlubKindWarrior:
-						baseDamage = 2
-					case world.SchlubKindVagrant:
-						baseDamage = 1
-					}
-					if baseDamage < 0 {
-						// Convert schlubs from the other mob to this one.
-						count := min(-baseDamage, len(other.Schlubs))
-						if count > 0 {
-							// Convert schlubs from the other mob to this one.
-							var schlubIDs []int
-							fmt.Println("count is", count, "other schlubs length is", len(other.Schlubs))
-							for i := 0; i < count; i++ {
-								schlubIDs = append(schlubIDs, int(other.Schlubs[i]))
-							}
-							mob.Schlubs = append(mob.Schlubs, other.Schlubs[:count]...)
-							other.Schlubs = other.Schlubs[count:]
-							t.State.EventBus.Publish(&event.MobConvert{
-								From: other.ID,
-								To:   mob.ID,
-								IDs:  schlubIDs,
-							})
-						}
-					} else if baseDamage > 0 {
-						baseDamage = min(baseDamage, len(other.Schlubs))
-						var schlubIDs []int
-						// Take schlubs from the _end_ of the other mob's schlubs.
-						for i := 0; i < baseDamage && i < len(other.Schlubs); i++ {
-							schlubIDs = append(schlubIDs, int(other.Schlubs[i]))
-						}
-
-						// Deal damage to the other mob.
-						t.State.EventBus.Publish(&event.MobDamage{
-							ID:         other.ID,
-							AttackerID: mob.ID,
-							IDs:        schlubIDs,
-						})
-						// Remove the schlubs from the other mob from the end.
-						other.Schlubs = other.Schlubs[len(other.Schlubs)-baseDamage:]
-					}
-					// Destroy the other mob if it has no schlubs left.
-					if len(other.Schlubs) == 0 {
-						t.State.EventBus.Publish(&event.MobDespawn{
-							ID: other.ID,
+			for _, other := range t.Continent.IntersectingMobs(mob) {
+				// An earlier merge or clash may have done away with us.
+				if t.Continent.FindMob(mob.ID) == nil {
+					break
+				}
+				// Players don't fight their own mobs, they join up instead.
+				if mob.OwnerID != 0 && mob.OwnerID == other.OwnerID {
+					if mob.Intersects(other) {
+						from, to := t.mergeOrder(mob, other)
+						t.EventBus.Publish(&event.MobMerge{
+							From: from.ID,
+							To:   to.ID,
 						})
-						// TODO: Maybe we should also check if the player has any mobs left.
 					}
+					continue
+				}
+				if mob.Intersects(other) {
+					t.Clash(mob, other)
 				}
 			}
 		}
 	})
-	t.EventBus.Subscribe((event.MobDamage{}).Type(), func(e event.Event) {
-		evt := e.(*event.MobDamage)
-		if mob := t.Continent.Mobs.FindByID(evt.ID); mob != nil {
-			// If the mob is dead, remove it.
-			if len(evt.IDs) == 0 {
-				t.Continent.Mobs.Remove(mob)
-				t.SendVisibleMobEvent(mob, &event.MobDespawn{
-					ID: mob.ID,
-				})
-			} else {
-				// Otherwise, just send the damage event.
-				t.SendVisibleMobEvent(mob, evt)
-			}
-		} else {
-			t.log.Warn("mob damage event received but mob not found", "id", evt.ID)
-		}
-	})
-	t.EventBus.Subscribe((event.MobConvert{}).Type(), func(e event.Event) {
-		evt := e.(*event.MobConvert)
-		fromMob := t.Continent.Mobs.FindByID(evt.From)
-		toMob := t.Continent.Mobs.FindByID(evt.To)
-		if fromMob == nil || toMob == nil {
-			t.log.Warn("mob convert event received but one or both mobs not found", "from", evt.From, "to", evt.To)
+	t.EventBus.Subscribe((event.MobMerge{}).Type(), func(e event.Event) {
+		evt := e.(*event.MobMerge)
+		fromMob := t.Continent.FindMob(evt.From)
+		toMob := t.Continent.FindMob(evt.To)
+		// Both mobs may have reported the same intersection, so one of them may already be gone.
+		if fromMob == nil || toMob == nil || fromMob == toMob {
+			t.log.Debug("mob merge event received but one or both mobs not found", "from", evt.From, "to", evt.To)
 			return
 		}
-		// If the from mob is dead, remove it.
-		if len(evt.IDs) == 0 {
-			t.Continent.Mobs.Remove(fromMob)
-			t.SendVisibleMobEvent(fromMob, &event.MobDespawn{
-				ID: fromMob.ID,
-			})
-		} else {
-			// Otherwise, convert the schlubs from the from mob to the to mob.
-			for _, id := range evt.IDs {
-				toMob.AddSchlub(world.SchlubID(id))
-				fromMob.RemoveSchlub(world.SchlubID(id))
-			}
-			// Send the convert event to the to mob.
-			t.SendVisibleMobEvent(toMob, &event.MobConvert{
-				From: evt.From,
-				To:   evt.To,
-				IDs:  evt.IDs,
-			})
+		if fromMob.OwnerID == 0 || fromMob.OwnerID != toMob.OwnerID {
+			t.log.Warn("mob merge event received for mobs with different owners", "from", evt.From, "to", evt.To)
+			return
 		}
+		t.MergeMob(fromMob, toMob)
 	})
 	t.EventBus.Subscribe((event.MobCreate{}).Type(), func(e event.Event) {
 		evt := e.(*event.MobCreate)
 		// Just send it.
-		if mob := t.Continent.Mobs.FindByID(evt.ID); mob != nil {
+		if mob := t.Continent.FindMob(evt.ID); mob != nil {
 			// Add the new schlubs to the mob.
 			for _, id := range evt.IDs {
 				mob.AddSchlub(world.SchlubID(id))
@@ -146,21 +93,24 @@ func (t *Table) Setup() {
 		msg := e.(*PlayerMessage)
 		switch evt := msg.msg.(type) {
 		case *request.Move:
-			if mob := t.Continent.Mobs.FindByID(msg.player.MobID); mob != nil {
-				mob.TargetX = evt.X
-				mob.TargetY = evt.Y
+			if mob := t.Continent.FindMob(evt.ID); mob != nil && mob.OwnerID == msg.player.ID {
+				// A direct order overrides any mob we were heading for.
+				mob.MoveTo(t.Continent, evt.X, evt.Y)
 				e := &event.MobMove{
 					ID:       mob.ID,
 					X:        evt.X,
 					Y:        evt.Y,
 					TargetID: mob.TargetID,
 				}
+				for _, waypoint := range mob.Path {
+					e.Path = append(e.Path, event.Waypoint{X: waypoint.X, Y: waypoint.Y})
+				}
 				t.SendVisibleMobEvent(mob, e)
 			} else {
-				t.log.Warn("move request received but mob not found", "mobID", msg.player.MobID)
+				t.log.Warn("move request received but mob not found or not owned", "mobID", evt.ID, "player", msg.player.ID)
 			}
 		case *request.Formation:
-			if mob := t.Continent.Mobs.FindByID(msg.player.MobID); mob != nil {
+			if mob := t.Continent.FindMob(evt.ID); mob != nil && mob.OwnerID == msg.player.ID {
 				// Eh... we're the arbiters of this.
 				if mob.OuterKind == world.SchlubKindVagrant || mob.OuterKind == 0 {
 					mob.OuterKind = world.SchlubKindMonk // Change the outer kind to monk
@@ -176,32 +126,87 @@ func (t *Table) Setup() {
 				}
 				t.SendVisibleMobEvent(mob, response)
 			} else {
-				t.log.Warn("formation request received but mob not found", "mobID", msg.player.MobID)
+				t.log.Warn("formation request received but mob not found or not owned", "mobID", evt.ID, "player", msg.player.ID)
+			}
+		case *request.Split:
+			mob := t.Continent.FindMob(evt.ID)
+			if mob == nil || mob.OwnerID != msg.player.ID {
+				t.log.Warn("split request received but mob not found or not owned", "mobID", evt.ID, "player", msg.player.ID)
+				return
 			}
+			var schlubs []world.SchlubID
+			for _, id := range evt.Schlubs {
+				schlub := world.SchlubID(id)
+				// The leader stays put.
+				if schlub.KindID() == int(world.SchlubKindPlayer) {
+					t.log.Warn("split request tried to split off the player", "mobID", evt.ID)
+					return
+				}
+				schlubs = append(schlubs, schlub)
+			}
+			if len(schlubs) == 0 || len(schlubs) >= len(mob.Schlubs) || !mob.HasSchlubs(schlubs...) {
+				t.log.Warn("split request received with invalid schlubs", "mobID", evt.ID, "count", len(schlubs))
+				return
+			}
+
+			split := t.SplitMob(mob, schlubs)
+			response := &event.MobSplit{
+				ID:      mob.ID,
+				To:      split.ID,
+				X:       split.X,
+				Y:       split.Y,
+				Schlubs: evt.Schlubs,
+			}
+			// Anyone who could see the source mob gets the split directly, otherwise they'll get a spawn through the usual visibility refresh.
+			for _, player := range t.players {
+				if slices.Contains(player.VisibleMobIDs, mob.ID) {
+					player.VisibleMobIDs = append(player.VisibleMobIDs, split.ID)
+					player.bus.Publish(response)
+				}
+			}
+		case *request.Merge:
+			fromMob := t.Continent.FindMob(evt.From)
+			toMob := t.Continent.FindMob(evt.To)
+			if fromMob == nil || toMob == nil || fromMob == toMob || fromMob.OwnerID != msg.player.ID || toMob.OwnerID != msg.player.ID {
+				t.log.Warn("merge request received but mobs not found or not owned", "from", evt.From, "to", evt.To, "player", msg.player.ID)
+				return
+			}
+			// Send the mob on its way, the actual merge happens once they touch.
+			fromMob, toMob = t.mergeOrder(fromMob, toMob)
+			fromMob.TargetID = toMob.ID
+			t.SendVisibleMobEvent(fromMob, &event.MobMove{
+				ID:       fromMob.ID,
+				X:        toMob.X,
+				Y:        toMob.Y,
+				TargetID: toMob.ID,
+			})
+		case *request.Follow:
+			if !msg.player.Spectator {
+				t.log.Warn("follow request received from a non-spectator", "player", msg.player.ID)
+				return
+			}
+			// Only players with mobs have any vision to follow.
+			if evt.ID != 0 {
+				if target := t.GetPlayer(evt.ID); target == nil || target.Spectator {
+					t.log.Warn("follow request received for unknown player", "player", msg.player.ID, "target", evt.ID)
+					return
+				}
+			}
+			msg.player.following = evt.ID
+		case *request.TechUse:
+			t.UseTech(msg.player, evt.Tech)
 		case *request.Construct:
-			if evt.Caravan >= int(world.SchlubKindCaravanVagrant) && evt.Caravan <= int(world.SchlubKindCaravanWarrior) {
-				if mob := t.Continent.Mobs.FindByID(msg.player.MobID); mob != nil {
-					if len(mob.Schlubs) < 3 {
-						// Not enough schlubs to construct a caravan.
+			if evt.Settlement {
+				t.FoundSettlement(msg.player)
+			} else if construct, ok := progression.CaravanConstructs[world.SchlubID(evt.Caravan)]; !ok || !msg.player.tech.CanConstruct(t.techs, construct) {
+				t.log.Warn("construct request received but caravan is locked", "player", msg.player.ID, "caravan", evt.Caravan)
+			} else {
+				if mob := t.Continent.FindMob(msg.player.MobID); mob != nil {
+					// A caravan takes 3 schlubs off the back of the mob, never the player.
+					if !t.SacrificeSchlubs(mob, 3) {
 						return
 					}
 
-					// Let's remove the last 3 schlubs from the mob.
-					count := 3
-					var schlubIDs []int
-					for i := 0; i < count && i < len(mob.Schlubs); i++ {
-						schlubIDs = append(schlubIDs, int(mob.Schlubs[i]))
-					}
-
-					// Deal damage to the mob.
-					t.SendVisibleMobEvent(mob, &event.MobDamage{
-						ID:         mob.ID,
-						AttackerID: mob.ID,
-						IDs:        schlubIDs,
-					})
-					// Remove schlubs from the mob.
-					mob.Schlubs = mob.Schlubs[:len(mob.Schlubs)-count]
-
 					// Add a some schlubs.
 					fam := t.FamilyID.NextSchlub()
 					t.FamilyID = fam
@@ -223,3 +228,18 @@ func (t *Table) Setup() {
 	// Create the director to manage the game contents
 	t.director = NewDirector(t)
 }
+
+// mergeOrder returns which of the two mobs should be folded into the other. A player's own mob always survives, otherwise the smaller mob is folded into the larger.
+func (t *Table) mergeOrder(a, b *world.Mob) (from, to *world.Mob) {
+	if player := t.GetPlayer(a.OwnerID); player != nil {
+		if a.ID == player.MobID {
+			return b, a
+		} else if b.ID == player.MobID {
+			return a, b
+		}
+	}
+	if len(a.Schlubs) > len(b.Schlubs) {
+		return b, a
+	}
+	return a, b
+}
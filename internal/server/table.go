@@ -2,14 +2,20 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/coder/websocket"
 	"github.com/ketMix/ebijam25/internal/log"
 	"github.com/ketMix/ebijam25/internal/message"
 	"github.com/ketMix/ebijam25/internal/message/event"
+	"github.com/ketMix/ebijam25/internal/message/request"
+	"github.com/ketMix/ebijam25/internal/progression"
 	"github.com/ketMix/ebijam25/internal/world"
 )
 
@@ -17,36 +23,62 @@ import (
 type Table struct {
 	world.State
 	director       *Director
+	techs          *progression.TechTree
 	log            *slog.Logger
 	ID             world.ID
-	open           bool
+	Name           string
+	MaxPlayers     int         // Max players, not counting spectators.
+	Code           string      // Join code for private tables, empty for public ones.
+	open           atomic.Bool // Whether the table has room for more players.
+	seated         atomic.Int32
+	watching       atomic.Int32
+	emptySince     time.Time
 	running        bool
 	players        []*Player
 	playerID       world.IDGenerator // ID generator for players in this table
 	playerAdd      chan *Player
 	playerLeave    chan *Player
-	playerMessages chan PlayerMessage // Channel for player messages
+	playerDrop     chan playerConn      // Channel for players whose connection dropped
+	playerResume   chan playerResume    // Channel for players coming back with a resume token
+	playerMessages chan PlayerMessage   // Channel for player messages
+	ResumeGrace    time.Duration        // How long a dropped player's mobs are kept alive for them to resume
+	Combat         world.CombatResolver // Rules for what happens when mobs clash
 	mobID          world.IDGenerator
 	resourceID     world.IDGenerator
+	itemID         world.IDGenerator
+	settlementID   world.IDGenerator
+	winner         world.ID  // Player who won the table, if anyone has yet.
 	close          chan bool // Channel to signal table closure
+	closed         bool      // Set under Tables.mu once the loop has finished, after which nothing may be sent to the table.
 }
 
 const (
-	debugSpawn = world.MaxSchlubsPerMob
+	debugSpawn          = world.MaxSchlubsPerMob
+	DefaultResumeGrace  = time.Second * 30
+	DefaultMaxPlayers   = 15
+	DefaultEmptyTimeout = time.Minute // How long a table can sit empty before it's reaped.
 )
 
 // NewTable makes a new table, dang.
 func NewTable(id world.ID) *Table {
-	return &Table{
+	t := &Table{
 		ID:             id,
+		Name:           fmt.Sprintf("Table %d", id),
+		MaxPlayers:     DefaultMaxPlayers,
 		log:            log.New("table", fmt.Sprintf("%d", id)),
 		playerAdd:      make(chan *Player, 10),        // Buffered channel for player additions
 		playerLeave:    make(chan *Player, 10),        // Buffered channel for player leave events
+		playerDrop:     make(chan playerConn, 10),     // Buffered channel for dropped connections
+		playerResume:   make(chan playerResume, 10),   // Buffered channel for resuming players
 		close:          make(chan bool, 1),            // Buffered channel for closing the table
 		playerMessages: make(chan PlayerMessage, 100), // Buffered channel for player messages
-		open:           true,
 		running:        true,
+		ResumeGrace:    DefaultResumeGrace,
+		Combat:         world.DefaultCombat{},
+		emptySince:     time.Now(),
 	}
+	t.open.Store(true)
+	return t
 }
 
 // Loop is our table's loop that runs in a goroutine. It receives new players, player leaves, player messages, and runs the table's update function at a fixed tickrate.
@@ -59,6 +91,7 @@ func (t *Table) Loop() {
 		case <-t.close:
 			t.log.Info("table closed")
 			t.running = false
+			t.open.Store(false)
 			// Boot all players from the table.
 			for _, player := range t.players {
 				player.conn.Close(websocket.StatusNormalClosure, "table closed")
@@ -66,101 +99,54 @@ func (t *Table) Loop() {
 			// FIXME: This should just get players into a new table.
 			return // Exit the loop if the table is closed
 		case msg := <-t.playerMessages:
+			// Pongs are timed as they arrive, waiting for the next tick would pad the round-trip.
+			if pong, ok := msg.msg.(*request.Pong); ok {
+				msg.player.Pong(pong.Seq)
+				continue
+			}
 			t.EventBus.Publish(&msg) // Publish the message to the event bus
 		case player := <-t.playerAdd:
+			// Garçon checks for room, but someone may have beaten them to the last seat.
+			if !player.Spectator && t.PlayerCount() >= t.MaxPlayers {
+				player.conn.Close(websocket.StatusTryAgainLater, "table is full")
+				continue
+			}
 			t.AddPlayer(player)
-			// Send a welcome message to the new player.
-			ctx, cancel := context.WithTimeout(context.Background(), time.Minute*5)
-			defer cancel()
-
-			// Create a new mob for the player.
-			x, y := t.director.GetSpawnPosition()
-			mob := t.Continent.NewMob(player.ID, t.mobID.Next(), x, y)
-			player.MobID = mob.ID // Assign the mob ID to the player
-
-			// Add a some schlubs.
-			fam := t.FamilyID.NextFamily()
-			t.FamilyID = fam
-
-			// Start with the player.
-			fam = fam.NextSchlub()
-			fam.SetKindID(int(world.SchlubKindPlayer)) // Set the kind to Player
-			mob.AddSchlub(fam)
-
-			// Perhaps a little unfair (due to some people getting' ROBBED), but let's give a few random schlubs to the player.
-			for range 8 {
-				if t.Continent.Fate.NumGen.Intn(100) < 75 { // 75% chance to add a random schlub
-					if t.Continent.Fate.NumGen.Intn(100) < 50 { // 50% chance for it to be from a diff. fam.
-						fam = fam.NextFamily()
-					} else {
-						fam = fam.NextSchlub() // Just get the next schlub in the same family
-					}
-					fam.SetKindID(int(world.SchlubKindVagrant)) // Set the kind to Vagrant
-					mob.AddSchlub(fam)
-				}
+			// Spectators are just here to watch.
+			if !player.Spectator {
+				t.SpawnPlayerMob(player)
+				t.GrantInnateTechs(player)
 			}
 
-			/*kindId := int(world.SchlubKindVagrant)
-			for range debugSpawn {
-				fam = fam.NextSchlub()
-				fam.SetKindID(kindId)
-				mob.AddSchlub(fam)
-				kindId++
-				if kindId > int(world.SchlubKindWarrior) {
-					kindId = int(world.SchlubKindVagrant)
-				}
-			}*/
-
-			welcome, _ := message.Encode(&event.MetaWelcome{
-				Username: player.Username,
-				ID:       player.ID,
-				Color:    player.Color,
-				MobID:    mob.ID,
-				Seed:     t.Seed,
-				Rate:     t.State.Tickrate,
-			})
-			player.conn.Write(ctx, websocket.MessageText, welcome)
-			// Also send a join event to all players.
+			// Send a welcome message to the new player.
+			t.SendWelcome(player, false)
+			// Also let everyone know about each other.
 			for _, p := range t.players {
 				if p.ID != player.ID { // Don't send to the new player
-					joinEvent, _ := message.Encode(&event.MetaJoin{
-						Username: player.Username,
-						Color:    player.Color,
-						ID:       player.ID,
+					p.Send(context.Background(), &event.MetaJoin{
+						Username:  player.Username,
+						Color:     player.Color,
+						ID:        player.ID,
+						Spectator: player.Spectator,
+					})
+					player.Send(context.Background(), &event.MetaJoin{
+						Username:  p.Username,
+						Color:     p.Color,
+						ID:        p.ID,
+						Spectator: p.Spectator,
 					})
-					player.conn.Write(ctx, websocket.MessageText, joinEvent)
-				}
-			}
-			// Mark the table as closed in there are 15+ players.
-			/*if len(t.players) >= 15 {
-				t.open = false // Close the table for new players
-			}*/
-		case player := <-t.playerLeave:
-			// Handle player leaving the table
-			for i, p := range t.players {
-				if p.ID == player.ID {
-					t.players = append(t.players[:i], t.players[i+1:]...) // Remove player from the slice
-					break
 				}
 			}
-			for _, p := range t.players {
-				leaveEvent, _ := message.Encode(&event.MetaLeave{
-					ID: player.ID,
-				})
-				p.conn.Write(context.Background(), websocket.MessageText, leaveEvent) // Notify other players about the player leaving
-			}
-			// TODO: Notify other players about the player leaving
-			for _, mob := range t.Continent.Mobs {
-				if mob.OwnerID == player.ID {
-					for _, p := range t.players {
-						t.Continent.RemoveMob(mob) // Remove the mob associated with the player
-						despawnEvent, _ := message.Encode(&event.MobDespawn{
-							ID: mob.ID,
-						})
-						p.conn.Write(context.Background(), websocket.MessageText, despawnEvent)
-					}
-				}
+			t.updateOccupancy()
+		case resume := <-t.playerResume:
+			t.ResumePlayer(resume)
+		case drop := <-t.playerDrop:
+			// Ignore connections the player has already moved on from.
+			if drop.player.conn == drop.conn && !drop.player.dropped {
+				t.DropPlayer(drop.player)
 			}
+		case player := <-t.playerLeave:
+			t.RemovePlayer(player)
 		case <-ticker.C:
 			// process da world, my final message
 			t.Update()
@@ -173,42 +159,237 @@ func (t *Table) Update() {
 	t.EventBus.ProcessEvents()
 
 	for _, player := range t.players {
+		// Nobody to send anything to, they'll get a fresh view if they come back.
+		if player.dropped {
+			player.bus.Discard()
+			continue
+		}
+		if player.HasFeature(message.FeatureHeartbeat) && time.Since(player.pingSent) >= message.PingInterval {
+			player.Ping(context.Background())
+		}
 		t.RefreshVisibleMobs(player)
+		t.RefreshVisibleItems(player)
+		t.RefreshVisibleResources(player)
+		t.RefreshVisibleSettlements(player)
 		// Also periodically refresh all player info.
 		player.lastRefresh++
 		if player.lastRefresh > 30 { // Refresh every 30 ticks
 			player.lastRefresh = 0
 			for _, p := range t.players {
-				if mob := t.Continent.Mobs.FindByID(p.MobID); mob != nil {
-					refreshEvent, _ := message.Encode(&event.MetaRefresh{
+				if owned := t.Continent.OwnedMobs(p.ID); len(owned) > 0 {
+					count := 0
+					for _, mob := range owned {
+						count += len(mob.Schlubs)
+					}
+					player.Send(context.Background(), &event.MetaRefresh{
 						ID:    p.ID,
-						Count: len(mob.Schlubs),
+						Count: count,
 					})
-					player.conn.Write(context.Background(), websocket.MessageText, refreshEvent)
 				}
 			}
 		}
 
 		player.bus.ProcessEvents()
 	}
+	// Let go of anyone who didn't make it back in time.
+	for _, player := range slices.Clone(t.players) {
+		if player.dropped && time.Since(player.droppedAt) > t.ResumeGrace {
+			t.log.Info("dropped player did not resume in time", "player", player.ID)
+			t.RemovePlayer(player)
+		}
+	}
+
+	// Yeet the table if it's been sitting empty for a while.
+	if len(t.players) > 0 {
+		t.emptySince = time.Now()
+	} else if time.Since(t.emptySince) > DefaultEmptyTimeout {
+		t.log.Info("table closed due to no players")
+		t.running = false
+		t.open.Store(false)
+		return
+	}
+
 	t.director.Update()
 	t.UpdateContinent()
 }
 
+// SpawnPlayerMob creates the player's starting mob and makes it their main mob.
+func (t *Table) SpawnPlayerMob(player *Player) *world.Mob {
+	x, y := t.director.GetSpawnPosition()
+	mob := t.Continent.NewMob(player.ID, t.mobID.Next(), x, y)
+	player.MobID = mob.ID // Assign the mob ID to the player
+
+	// Add a some schlubs.
+	fam := t.FamilyID.NextFamily()
+	t.FamilyID = fam
+
+	// Start with the player.
+	fam = fam.NextSchlub()
+	fam.SetKindID(int(world.SchlubKindPlayer)) // Set the kind to Player
+	mob.AddSchlub(fam)
+
+	// Perhaps a little unfair (due to some people getting' ROBBED), but let's give a few random schlubs to the player.
+	for range 8 {
+		if t.Continent.Fate.NumGen.Intn(100) < 75 { // 75% chance to add a random schlub
+			if t.Continent.Fate.NumGen.Intn(100) < 50 { // 50% chance for it to be from a diff. fam.
+				fam = fam.NextFamily()
+			} else {
+				fam = fam.NextSchlub() // Just get the next schlub in the same family
+			}
+			fam.SetKindID(int(world.SchlubKindVagrant)) // Set the kind to Vagrant
+			mob.AddSchlub(fam)
+		}
+	}
+
+	/*kindId := int(world.SchlubKindVagrant)
+	for range debugSpawn {
+		fam = fam.NextSchlub()
+		fam.SetKindID(kindId)
+		mob.AddSchlub(fam)
+		kindId++
+		if kindId > int(world.SchlubKindWarrior) {
+			kindId = int(world.SchlubKindVagrant)
+		}
+	}*/
+
+	return mob
+}
+
+// SendWelcome sends the player everything they need to get going, including a fresh resume token.
+func (t *Table) SendWelcome(player *Player, resumed bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute*5)
+	defer cancel()
+
+	player.token = newToken(t.ID)
+	player.Send(ctx, &event.MetaWelcome{
+		Username:  player.Username,
+		ID:        player.ID,
+		Color:     player.Color,
+		MobID:     player.MobID,
+		Seed:      t.Seed,
+		Rate:      t.State.Tickrate,
+		Codec:     player.codec.Name(),
+		Version:   message.ProtocolVersion,
+		Features:  player.features,
+		Token:     player.token,
+		Resumed:   resumed,
+		Spectator: player.Spectator,
+		TableName: t.Name,
+		Code:      t.Code,
+	})
+	t.SendTechs(player)
+	t.SendTerritory(player)
+}
+
+// DropPlayer marks the player as disconnected. Their mobs stay on the table until they resume or ResumeGrace runs out.
+func (t *Table) DropPlayer(player *Player) {
+	player.dropped = true
+	player.droppedAt = time.Now()
+	t.log.Info("player dropped, holding their mobs", "player", player.ID, "grace", t.ResumeGrace)
+}
+
+// ResumePlayer reattaches a new connection to the player holding the resume token and resyncs everything they can see.
+func (t *Table) ResumePlayer(resume playerResume) {
+	var player *Player
+	for _, p := range t.players {
+		if p.token == resume.token {
+			player = p
+			break
+		}
+	}
+	if player == nil {
+		resume.conn.Close(websocket.StatusPolicyViolation, "session expired, please rejoin")
+		return
+	}
+
+	// The old connection may not have noticed it's dead yet.
+	if player.conn != resume.conn {
+		player.conn.Close(websocket.StatusPolicyViolation, "resumed elsewhere")
+	}
+	player.conn = resume.conn
+	player.dropped = false
+
+	// Start them off fresh, everything they can see gets sent again on the next refresh.
+	player.bus.Discard()
+	player.VisibleMobIDs = nil
+	player.visibleItems = nil
+	player.visibleResources = nil
+	player.visibleSettlements = nil
+	t.SendWelcome(player, true)
+	go t.listen(player, resume.conn)
+	t.log.Info("player resumed", "player", player.ID)
+}
+
+// RemovePlayer removes the player from the table and despawns their mobs.
+func (t *Table) RemovePlayer(player *Player) {
+	t.players = slices.DeleteFunc(t.players, func(p *Player) bool {
+		return p.ID == player.ID
+	})
+	for _, p := range t.players {
+		p.Send(context.Background(), &event.MetaLeave{ // Notify other players about the player leaving
+			ID: player.ID,
+		})
+		// Spectators following them go back to seeing everything.
+		if p.following == player.ID {
+			p.following = 0
+		}
+	}
+	for _, mob := range t.Continent.OwnedMobs(player.ID) {
+		t.Continent.RemoveMob(mob) // Remove the mob associated with the player
+		for _, p := range t.players {
+			if slices.Contains(p.VisibleMobIDs, mob.ID) {
+				p.Send(context.Background(), &event.MobDespawn{
+					ID: mob.ID,
+				})
+				p.VisibleMobIDs = slices.DeleteFunc(p.VisibleMobIDs, func(id world.ID) bool {
+					return id == mob.ID
+				})
+			}
+		}
+	}
+	t.ReleaseFiefs(player.ID)
+	t.updateOccupancy()
+	t.log.Info("player removed", "player", player.ID)
+}
+
+// updateOccupancy refreshes the counts shown in the lobby and closes the table to new players once it's full.
+func (t *Table) updateOccupancy() {
+	seated := t.PlayerCount()
+	t.seated.Store(int32(seated))
+	t.watching.Store(int32(len(t.players) - seated))
+	t.open.Store(t.running && seated < t.MaxPlayers)
+}
+
+// PlayerCount returns the number of players at the table, not counting spectators.
+func (t *Table) PlayerCount() int {
+	count := 0
+	for _, player := range t.players {
+		if !player.Spectator {
+			count++
+		}
+	}
+	return count
+}
+
+// GetPlayer returns the player with the given ID, if they are at this table.
+func (t *Table) GetPlayer(id world.ID) *Player {
+	for _, player := range t.players {
+		if player.ID == id {
+			return player
+		}
+	}
+	return nil
+}
+
 // AddPlayer adds a player, hooks up buses, and starts a goroutine to handle player messages.
 func (t *Table) AddPlayer(player *Player) {
 	player.ID = t.playerID.Next() // Assign a new ID to the player
 	t.players = append(t.players, player)
 	// Hook up that busy ;) (this writes all events received on the bus to the player's websocket connection)
 	player.bus.SubscribePrefix("", func(e event.Event) {
-		data, err := message.Encode(e)
-		if err != nil {
-			fmt.Println("error encoding message:", err)
-			return
-		}
 		ctx, cancel := context.WithTimeout(context.Background(), time.Minute*5)
 		defer cancel()
-		err = player.conn.Write(ctx, websocket.MessageText, data)
+		err := player.Send(ctx, e)
 		if err != nil {
 			fmt.Println("error writing to player connection:", err)
 			return
@@ -216,59 +397,150 @@ func (t *Table) AddPlayer(player *Player) {
 	})
 
 	// It's a bit crap, but we need to spawn a new goroutine for each player.
-	go func() {
-		for {
-			ctx, cancel := context.WithTimeout(context.Background(), time.Minute*5)
-			player.cancel = cancel // Store the cancel function in the player struct
-			kind, data, err := player.conn.Read(ctx)
-			if err != nil {
-				fmt.Println("error reading from player connection:", err)
-				break
-			}
-			if kind != websocket.MessageText {
-				continue
-			}
+	go t.listen(player, player.conn)
+}
 
-			msg, err := message.Decode(data)
-			if err != nil {
-				fmt.Println("error decoding message:", err)
-				break
-			}
-			t.playerMessages <- PlayerMessage{
-				player: player,
-				msg:    msg,
-			}
+// listen reads messages from one of the player's connections until it fails or they leave.
+func (t *Table) listen(player *Player, conn *websocket.Conn) {
+	for {
+		// Players that answer our pings can be given up on much sooner.
+		timeout := time.Minute * 5
+		if player.HasFeature(message.FeatureHeartbeat) {
+			timeout = message.IdleTimeout
 		}
-
-		// Yeet the table if there are no players left and we closed it.
-		if len(t.players) == 0 && !t.open {
-			t.close <- true // Signal the table to close
-			t.log.Info("table closed due to no players")
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		player.cancel = cancel // Store the cancel function in the player struct
+		kind, data, err := conn.Read(ctx)
+		if err != nil {
+			fmt.Println("error reading from player connection:", err)
+			break
+		}
+		// Players may speak whichever codec they like, the frame kind tells us which.
+		msg, err := message.CodecFor(kind == websocket.MessageBinary).Decode(data)
+		if errors.Is(err, message.ErrUnknownType) {
+			t.log.Warn("unknown message from player", "player", player.ID, "error", err)
+			continue
+		} else if err != nil {
+			fmt.Println("error decoding message:", err)
+			break
+		}
+		// A proper goodbye means there's nothing to hold on to.
+		if _, ok := msg.(*request.Leave); ok {
+			t.playerLeave <- player
+			conn.Close(websocket.StatusNormalClosure, "bai")
 			return
 		}
+		t.playerMessages <- PlayerMessage{
+			player: player,
+			msg:    msg,
+		}
+	}
 
-		t.playerLeave <- player // Notify the table that the player is leaving
-		player.conn.Close(websocket.StatusNormalClosure, "bai")
-	}()
+	t.playerDrop <- playerConn{player: player, conn: conn} // Hold on to the player for a bit in case they come back
+	conn.Close(websocket.StatusNormalClosure, "bai")
 }
 
 // Tables is our tables.
 type Tables struct {
-	tables []*Table
-	idGen  world.IDGenerator
+	mu          sync.Mutex
+	tables      []*Table
+	idGen       world.IDGenerator
+	resumeGrace time.Duration // Applied to new tables if set.
+}
+
+// GetTable returns the table with the given ID, if it exists.
+func (t *Tables) GetTable(id world.ID) *Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, table := range t.tables {
+		if table.ID == id {
+			return table
+		}
+	}
+	return nil
 }
 
-// AcquireOpenTable either creates a new open table and spawns a goroutine to handle it or returns an existing one.
+// AcquireOpenTable either creates a new open table and spawns a goroutine to handle it or returns an existing one. Private tables are never handed out.
 func (t *Tables) AcquireOpenTable() *Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
 	for _, table := range t.tables {
-		if table.open {
+		if table.open.Load() && table.Code == "" {
 			return table
 		}
 	}
+	return t.newTable("", 0, "")
+}
+
+// newTable creates a table and spins it up, leaving the name and max players at their defaults if unset. The caller must hold t.mu.
+func (t *Tables) newTable(name string, maxPlayers int, code string) *Table {
 	newTable := NewTable(t.idGen.Next())
+	if t.resumeGrace > 0 {
+		newTable.ResumeGrace = t.resumeGrace
+	}
+	if name != "" {
+		newTable.Name = name
+	}
+	if maxPlayers > 0 {
+		newTable.MaxPlayers = maxPlayers
+	}
+	newTable.Code = code
 	newTable.Setup()
 	t.tables = append(t.tables, newTable)
 	// Spin it up...
-	go newTable.Loop()
+	go func() {
+		newTable.Loop()
+		t.removeTable(newTable)
+	}()
 	return newTable
 }
+
+// removeTable forgets about a table once its loop has finished, turning away anyone who snuck in at the last moment.
+func (t *Tables) removeTable(table *Table) {
+	t.mu.Lock()
+	table.closed = true
+	t.tables = slices.DeleteFunc(t.tables, func(other *Table) bool {
+		return other == table
+	})
+	t.mu.Unlock()
+	for {
+		select {
+		case player := <-table.playerAdd:
+			player.conn.Close(websocket.StatusTryAgainLater, "table closed")
+		case resume := <-table.playerResume:
+			resume.conn.Close(websocket.StatusPolicyViolation, "session expired, please rejoin")
+		default:
+			return
+		}
+	}
+}
+
+// seatPlayer hands a joining player over to the table. It returns false if the table has closed or is too backed up to take them.
+func (t *Tables) seatPlayer(table *Table, player *Player) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if table.closed {
+		return false
+	}
+	select {
+	case table.playerAdd <- player:
+		return true
+	default:
+		return false
+	}
+}
+
+// resumePlayer hands a returning connection over to the table. It returns false if the table has closed or is too backed up to take it.
+func (t *Tables) resumePlayer(table *Table, resume playerResume) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if table.closed {
+		return false
+	}
+	select {
+	case table.playerResume <- resume:
+		return true
+	default:
+		return false
+	}
+}
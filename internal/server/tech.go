@@ -0,0 +1,158 @@
+package server
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ketMix/ebijam25/internal/message/event"
+	"github.com/ketMix/ebijam25/internal/progression"
+	"github.com/ketMix/ebijam25/internal/world"
+)
+
+// techEffects are what usable skills actually do when used. Every usable skill in the tech tree needs one.
+var techEffects = map[string]func(t *Table, mob *world.Mob){
+	"recruit": func(t *Table, mob *world.Mob) {
+		t.RecruitSchlubs(mob, world.SchlubKindVagrant, 3)
+	},
+	"bless": func(t *Table, mob *world.Mob) {
+		t.RetrainSchlubs(mob, world.SchlubKindVagrant, world.SchlubKindMonk, 3)
+	},
+	"drill": func(t *Table, mob *world.Mob) {
+		t.RetrainSchlubs(mob, world.SchlubKindVagrant, world.SchlubKindWarrior, 3)
+	},
+}
+
+// GrantInnateTechs gives the player every skill everyone starts with.
+func (t *Table) GrantInnateTechs(player *Player) {
+	for _, name := range t.techs.Innate() {
+		player.tech.Add(name)
+	}
+}
+
+// SendTechs sends the player every skill they've acquired so far.
+func (t *Table) SendTechs(player *Player) {
+	for _, name := range player.tech.Acquired() {
+		t.sendTechUnlock(player, name)
+	}
+}
+
+func (t *Table) sendTechUnlock(player *Player, name string) {
+	skill, tech := t.techs.Skill(name)
+	if skill == nil {
+		return
+	}
+	player.bus.Publish(&event.TechUnlock{
+		Tech:       tech.Name,
+		Skill:      skill.Name,
+		Constructs: skill.Constructs,
+	})
+}
+
+// UseTech acquires the skill for the player if they can pay for it, or uses it if they already have it.
+func (t *Table) UseTech(player *Player, name string) {
+	mob := t.Continent.FindMob(player.MobID)
+	if mob == nil || mob.OwnerID != player.ID {
+		t.log.Warn("tech request received but player has no mob", "player", player.ID, "skill", name)
+		return
+	}
+
+	skill, err := player.tech.CanAcquire(t.techs, name)
+	if err == nil {
+		// Skills are paid for in schlubs, but the leader isn't up for grabs.
+		if skill.Cost > 0 && !t.SacrificeSchlubs(mob, skill.Cost) {
+			t.log.Debug("tech request received but player can't afford it", "player", player.ID, "skill", name, "cost", skill.Cost)
+			return
+		}
+		player.tech.Add(name)
+		t.sendTechUnlock(player, name)
+		t.log.Info("tech acquired", "player", player.ID, "skill", name)
+		return
+	} else if !errors.Is(err, progression.ErrAlreadyAcquired) {
+		t.log.Warn("tech request received but skill can't be acquired", "player", player.ID, "skill", name, "error", err)
+		return
+	}
+
+	skill, err = player.tech.CanUse(t.techs, name)
+	if err != nil {
+		t.log.Warn("tech request received but skill can't be used", "player", player.ID, "skill", name, "error", err)
+		return
+	}
+	cooldown := time.Duration(skill.Cooldown * float64(time.Second))
+	if time.Since(player.techUsed[name]) < cooldown {
+		t.log.Debug("tech request received but skill is cooling down", "player", player.ID, "skill", name)
+		return
+	}
+	effect, ok := techEffects[name]
+	if !ok {
+		t.log.Error("usable skill has no effect", "skill", name)
+		return
+	}
+	if player.techUsed == nil {
+		player.techUsed = make(map[string]time.Time)
+	}
+	player.techUsed[name] = time.Now()
+	effect(t, mob)
+	player.bus.Publish(&event.TechUsed{
+		Skill: name,
+	})
+}
+
+// SacrificeSchlubs removes count schlubs from the back of the mob, never the player. It returns false and leaves the mob alone if there aren't enough.
+func (t *Table) SacrificeSchlubs(mob *world.Mob, count int) bool {
+	var schlubs []world.SchlubID
+	for i := len(mob.Schlubs) - 1; i >= 0 && len(schlubs) < count; i-- {
+		if mob.Schlubs[i].KindID() != int(world.SchlubKindPlayer) {
+			schlubs = append(schlubs, mob.Schlubs[i])
+		}
+	}
+	if len(schlubs) < count {
+		return false
+	}
+	t.removeSchlubs(mob, schlubs)
+	return true
+}
+
+// RecruitSchlubs adds count new schlubs of the given kind to the mob.
+func (t *Table) RecruitSchlubs(mob *world.Mob, kind world.SchlubID, count int) {
+	var ids []int
+	for range count {
+		fam := t.FamilyID.NextSchlub()
+		t.FamilyID = fam
+		fam.SetKindID(int(kind))
+		mob.AddSchlub(fam)
+		ids = append(ids, int(fam))
+	}
+	t.SendVisibleMobEvent(mob, &event.MobCreate{
+		ID:  mob.ID,
+		IDs: ids,
+	})
+}
+
+// RetrainSchlubs swaps up to count schlubs of one kind in the mob for fresh schlubs of another.
+func (t *Table) RetrainSchlubs(mob *world.Mob, from, to world.SchlubID, count int) {
+	var schlubs []world.SchlubID
+	for _, schlub := range mob.Schlubs {
+		if len(schlubs) < count && schlub.KindID() == int(from) {
+			schlubs = append(schlubs, schlub)
+		}
+	}
+	if len(schlubs) == 0 {
+		return
+	}
+	t.removeSchlubs(mob, schlubs)
+	t.RecruitSchlubs(mob, to, len(schlubs))
+}
+
+// removeSchlubs removes the schlubs from the mob and tells everyone watching, as if the mob had hurt itself.
+func (t *Table) removeSchlubs(mob *world.Mob, schlubs []world.SchlubID) {
+	var ids []int
+	for _, schlub := range schlubs {
+		ids = append(ids, int(schlub))
+	}
+	mob.RemoveSchlub(schlubs...)
+	t.SendVisibleMobEvent(mob, &event.MobDamage{
+		ID:         mob.ID,
+		AttackerID: mob.ID,
+		IDs:        ids,
+	})
+}
@@ -0,0 +1,90 @@
+package server
+
+import (
+	"github.com/ketMix/ebijam25/internal/message/event"
+	"github.com/ketMix/ebijam25/internal/world"
+)
+
+// TerritoryWinShare is the share of the continent's fiefs a player has to own to win the table.
+const TerritoryWinShare = 0.1
+
+// ClaimFiefs runs a claim round. A fief held by only one player's mobs goes a round further towards being theirs, while fiefs held by nobody or fought over let any claim lapse.
+func (t *Table) ClaimFiefs() {
+	holders := make(map[int]world.ID)
+	for _, mob := range t.Continent.Mobs {
+		// Barbarians don't claim anything, nor do they get in the way.
+		if mob.OwnerID == 0 {
+			continue
+		}
+		i := t.Continent.FiefIndex(mob.X, mob.Y)
+		if i < 0 {
+			continue
+		}
+		if holder, ok := holders[i]; !ok {
+			holders[i] = mob.OwnerID
+		} else if holder != mob.OwnerID {
+			holders[i] = 0
+		}
+	}
+	for i, fief := range t.Continent.Fiefs {
+		if fief.Hold(holders[i]) {
+			t.Continent.SetFiefOwner(fief, holders[i])
+			t.log.Info("fief claimed", "fief", i, "owner", fief.OwnerID)
+			t.sendFiefClaim(i, fief.OwnerID)
+			t.checkTerritoryWin(fief.OwnerID)
+		}
+	}
+}
+
+// ReleaseFiefs gives up every fief the owner has claimed or is claiming.
+func (t *Table) ReleaseFiefs(owner world.ID) {
+	for i, fief := range t.Continent.Fiefs {
+		if fief.Claimant == owner {
+			fief.Claimant = 0
+			fief.Claim = 0
+		}
+		if fief.OwnerID == owner {
+			t.Continent.SetFiefOwner(fief, 0)
+			t.sendFiefClaim(i, 0)
+		}
+	}
+}
+
+// SendTerritory sends the player who owns what.
+func (t *Table) SendTerritory(player *Player) {
+	for i, fief := range t.Continent.Fiefs {
+		if fief.OwnerID != 0 {
+			player.bus.Publish(&event.FiefClaim{
+				Fief:  i,
+				Owner: fief.OwnerID,
+			})
+		}
+	}
+}
+
+// sendFiefClaim tells everyone the fief changed hands. Territory is no secret.
+func (t *Table) sendFiefClaim(fief int, owner world.ID) {
+	for _, player := range t.players {
+		player.bus.Publish(&event.FiefClaim{
+			Fief:  fief,
+			Owner: owner,
+		})
+	}
+}
+
+// checkTerritoryWin announces the owner as the table's winner if they now own enough of the continent. Only the first player to get there wins.
+func (t *Table) checkTerritoryWin(owner world.ID) {
+	if t.winner != 0 {
+		return
+	}
+	if float64(len(t.Continent.OwnedFiefs(owner))) < TerritoryWinShare*float64(len(t.Continent.Fiefs)) {
+		return
+	}
+	t.winner = owner
+	t.log.Info("territory win", "player", owner)
+	for _, player := range t.players {
+		player.bus.Publish(&event.GameWin{
+			ID: owner,
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/ketMix/ebijam25/internal/world"
+)
+
+// emptyTable returns a table with nobody on its continent.
+func emptyTable() *Table {
+	t := NewTable(1)
+	t.Setup()
+	t.Continent.ClearMobs()
+	return t
+}
+
+// middle returns the middle of the fief at the given index.
+func middle(t *Table, i int) (float64, float64) {
+	fief := t.Continent.Fiefs[i]
+	return fief.X + world.FiefPixelSpan/2, fief.Y + world.FiefPixelSpan/2
+}
+
+func TestClaimFiefs(t *testing.T) {
+	table := emptyTable()
+	x, y := middle(table, 0)
+	table.Continent.NewMob(1, 1, x, y)
+	// Player 2 and a barbarian fight over the next fief, the barbarian doesn't count.
+	x, y = middle(table, 1)
+	table.Continent.NewMob(2, 2, x, y)
+	table.Continent.NewMob(0, 3, x, y)
+	// Players 1 and 2 both hold the one after that.
+	x, y = middle(table, 2)
+	table.Continent.NewMob(1, 4, x, y)
+	table.Continent.NewMob(2, 5, x, y)
+
+	for range world.FiefClaimRounds - 1 {
+		table.ClaimFiefs()
+	}
+	if owner := table.Continent.Fiefs[0].OwnerID; owner != 0 {
+		t.Fatalf("fief taken by %d a round early", owner)
+	}
+	table.ClaimFiefs()
+	if owner := table.Continent.Fiefs[0].OwnerID; owner != 1 {
+		t.Errorf("held fief is owned by %d, want 1", owner)
+	}
+	if owner := table.Continent.Fiefs[1].OwnerID; owner != 2 {
+		t.Errorf("fief held alongside a barbarian is owned by %d, want 2", owner)
+	}
+	if fief := table.Continent.Fiefs[2]; fief.OwnerID != 0 || fief.Claim != 0 {
+		t.Errorf("contested fief is owned by %d with a claim of %d", fief.OwnerID, fief.Claim)
+	}
+	if owned := table.Continent.OwnedFiefs(1); len(owned) != 1 || owned[0] != table.Continent.Fiefs[0] {
+		t.Errorf("player 1 owns %d fiefs, want 1", len(owned))
+	}
+
+	table.ReleaseFiefs(1)
+	if table.Continent.Fiefs[0].OwnerID != 0 || len(table.Continent.OwnedFiefs(1)) != 0 {
+		t.Errorf("player 1 still owns fiefs after releasing them")
+	}
+	if table.Continent.Fiefs[1].OwnerID != 2 {
+		t.Errorf("releasing player 1's fiefs took player 2's")
+	}
+}
+
+func TestTerritoryWin(t *testing.T) {
+	table := emptyTable()
+	needed := int(TerritoryWinShare*float64(len(table.Continent.Fiefs))) + 1
+	for i := range needed - 1 {
+		table.Continent.SetFiefOwner(table.Continent.Fiefs[i], 1)
+	}
+	table.checkTerritoryWin(1)
+	if table.winner != 0 {
+		t.Fatalf("player 1 won with only %d fiefs", needed-1)
+	}
+
+	// Taking one more fief tips it over.
+	x, y := middle(table, needed)
+	table.Continent.NewMob(1, 1, x, y)
+	for range world.FiefClaimRounds {
+		table.ClaimFiefs()
+	}
+	if table.winner != 1 {
+		t.Fatalf("player 1 didn't win with %d fiefs", len(table.Continent.OwnedFiefs(1)))
+	}
+
+	// Only the first player to get there wins.
+	for i := range needed {
+		table.Continent.SetFiefOwner(table.Continent.Fiefs[len(table.Continent.Fiefs)-1-i], 2)
+	}
+	table.checkTerritoryWin(2)
+	if table.winner != 1 {
+		t.Errorf("player 2 took the win from player 1")
+	}
+}
@@ -0,0 +1,32 @@
+package world
+
+const (
+	AgeYoung    = 4  // Schlubs younger than this are still growing and fight worse.
+	AgeElder    = 24 // Schlubs this old or older are past their prime.
+	AgeMax      = 31 // Schlubs this old die the next time they'd age.
+	AgeInterval = 20 // Aging rounds between birthdays for any one schlub.
+)
+
+// Ages returns true if the schlub gets older at all. Leaders are timeless and caravans are just carts.
+func (s SchlubID) Ages() bool {
+	switch SchlubID(s.KindID()) {
+	case SchlubKindVagrant, SchlubKindMonk, SchlubKindWarrior:
+		return true
+	}
+	return false
+}
+
+// Young returns true if the schlub is still growing.
+func (s SchlubID) Young() bool {
+	return s.Ages() && s.AgeID() < AgeYoung
+}
+
+// Elder returns true if the schlub is past its prime.
+func (s SchlubID) Elder() bool {
+	return s.Ages() && s.AgeID() >= AgeElder
+}
+
+// Birthday returns true if the schlub is due to age in the given aging round. Schlubs are spread out over AgeInterval rounds so a family doesn't all die at once.
+func (s SchlubID) Birthday(round int) bool {
+	return s.Ages() && (round+s.FamilyID()+s.SchlubID())%AgeInterval == 0
+}
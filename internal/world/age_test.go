@@ -0,0 +1,92 @@
+package world
+
+import "testing"
+
+func TestAges(t *testing.T) {
+	for kind, ages := range map[SchlubID]bool{
+		SchlubKindPlayer:         false,
+		SchlubKindVagrant:        true,
+		SchlubKindMonk:           true,
+		SchlubKindWarrior:        true,
+		SchlubKindCaravanVagrant: false,
+		SchlubKindCaravanMonk:    false,
+		SchlubKindCaravanWarrior: false,
+	} {
+		if got := schlub(kind, 0, ItemNone).Ages(); got != ages {
+			t.Errorf("kind %d ages = %v, want %v", kind, got, ages)
+		}
+		// The timeless are neither young nor old, whatever their age bits say.
+		if !ages && (schlub(kind, 0, ItemNone).Young() || schlub(kind, AgeMax, ItemNone).Elder()) {
+			t.Errorf("kind %d has an age", kind)
+		}
+	}
+}
+
+func TestGrowingUp(t *testing.T) {
+	s := schlub(SchlubKindMonk, 0, ItemBanner)
+	for age := 0; age <= AgeMax; age++ {
+		if s.AgeID() != age {
+			t.Fatalf("age is %d, want %d", s.AgeID(), age)
+		}
+		if s.Young() != (age < AgeYoung) || s.Elder() != (age >= AgeElder) {
+			t.Fatalf("at %d young = %v, elder = %v", age, s.Young(), s.Elder())
+		}
+		if SchlubID(s.KindID()) != SchlubKindMonk || Item(s.ItemID()) != ItemBanner {
+			t.Fatalf("growing up to %d changed the schlub into %v", age, s)
+		}
+		if age < AgeMax {
+			s.SetAgeID(s.AgeID() + 1)
+		}
+	}
+}
+
+func TestBirthday(t *testing.T) {
+	family := SchlubID(0).NextFamily()
+	family.SetKindID(int(SchlubKindVagrant))
+	schlubs := family.NextSchlubs(AgeInterval)
+	// Everyone has exactly one birthday every AgeInterval rounds, and a family's birthdays are spread out.
+	birthdays := make(map[int]int)
+	for _, s := range schlubs {
+		count := 0
+		for round := range 3 * AgeInterval {
+			if s.Birthday(round) {
+				count++
+				birthdays[round%AgeInterval]++
+			}
+		}
+		if count != 3 {
+			t.Errorf("%v had %d birthdays in %d rounds", s, count, 3*AgeInterval)
+		}
+	}
+	for round, count := range birthdays {
+		if count != 3 {
+			t.Errorf("%d schlubs share round %d", count/3, round)
+		}
+	}
+	var leader SchlubID
+	for round := range AgeInterval {
+		if leader.Birthday(round) {
+			t.Errorf("the leader had a birthday")
+		}
+	}
+}
+
+func TestAgeStats(t *testing.T) {
+	for _, kind := range []SchlubID{SchlubKindVagrant, SchlubKindMonk, SchlubKindWarrior} {
+		prime := *StatsFor([]SchlubID{schlub(kind, adult, ItemNone)})
+		young := *StatsFor([]SchlubID{schlub(kind, 0, ItemNone)})
+		elder := *StatsFor([]SchlubID{schlub(kind, AgeElder, ItemNone)})
+		want := prime.Apply(&Stats{Strength: -1, Endurance: -1})
+		if young != *want {
+			t.Errorf("young kind %d = %+v, want %+v", kind, young, *want)
+		}
+		want = prime.Apply(&Stats{Strength: -1, Agility: -1, Charisma: 1})
+		if elder != *want {
+			t.Errorf("elder kind %d = %+v, want %+v", kind, elder, *want)
+		}
+	}
+	// Age bits on a leader mean nothing.
+	if young, prime := *StatsFor([]SchlubID{schlub(SchlubKindPlayer, 0, ItemNone)}), *StatsFor([]SchlubID{schlub(SchlubKindPlayer, AgeElder, ItemNone)}); young != prime {
+		t.Errorf("leader's stats changed with age, %+v vs %+v", young, prime)
+	}
+}
@@ -0,0 +1,50 @@
+package world
+
+// CombatResult is what became of the defender when two mobs clashed.
+type CombatResult struct {
+	Converted []SchlubID // Schlubs that left the defender to join the attacker.
+	Slain     []SchlubID // Schlubs of the defender that died.
+	Despawn   bool       // Whether the defender has no schlubs left and should be removed.
+}
+
+// CombatResolver decides what happens when an attacker runs into a defender. Resolvers must be deterministic and must not modify either mob, the caller applies the result.
+type CombatResolver interface {
+	Resolve(attacker, defender *Mob) CombatResult
+}
+
+// DefaultCombat is the standard rules. The attacker's outer kind picks whether it converts or slays and how many, and stats scale that up or down. Conversions take from the front of the defender, slayings from the back.
+type DefaultCombat struct{}
+
+// Resolve resolves a single clash.
+func (DefaultCombat) Resolve(attacker, defender *Mob) CombatResult {
+	var result CombatResult
+	if len(defender.Schlubs) == 0 {
+		result.Despawn = true
+		return result
+	}
+
+	switch attacker.OuterKind {
+	case SchlubKindPlayer:
+		result.Converted = frontSchlubs(defender, attacker.Conversions(defender, 10))
+	case SchlubKindMonk:
+		result.Converted = frontSchlubs(defender, attacker.Conversions(defender, 1))
+	case SchlubKindWarrior:
+		result.Slain = backSchlubs(defender, attacker.Damage(defender, 2))
+	case SchlubKindVagrant:
+		result.Slain = backSchlubs(defender, attacker.Damage(defender, 1))
+	}
+	result.Despawn = len(result.Converted)+len(result.Slain) >= len(defender.Schlubs)
+	return result
+}
+
+// frontSchlubs returns up to count schlubs from the front of the mob.
+func frontSchlubs(mob *Mob, count int) []SchlubID {
+	count = min(count, len(mob.Schlubs))
+	return append([]SchlubID(nil), mob.Schlubs[:count]...)
+}
+
+// backSchlubs returns up to count schlubs from the back of the mob.
+func backSchlubs(mob *Mob, count int) []SchlubID {
+	count = min(count, len(mob.Schlubs))
+	return append([]SchlubID(nil), mob.Schlubs[len(mob.Schlubs)-count:]...)
+}
@@ -0,0 +1,90 @@
+package world
+
+import (
+	"slices"
+	"testing"
+)
+
+// even are stats that leave ClashCount at its base either way round.
+var even = Stats{Strength: RankIron, Agility: RankIron, Charisma: RankIron, Endurance: RankIron, Luck: RankIron}
+
+// numbered returns a mob with count schlubs numbered from 1, so it's easy to tell which ones were taken.
+func numbered(count int) *Mob {
+	stats := even
+	mob := &Mob{Stats: &stats}
+	for i := range count {
+		mob.Schlubs = append(mob.Schlubs, SchlubID(i+1))
+	}
+	return mob
+}
+
+func TestDefaultCombat(t *testing.T) {
+	tests := []struct {
+		name      string
+		kind      SchlubID
+		defenders int
+		converted []SchlubID
+		slain     []SchlubID
+		despawn   bool
+	}{
+		{name: "player converts from the front", kind: SchlubKindPlayer, defenders: 15, converted: []SchlubID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}},
+		{name: "player converts everyone", kind: SchlubKindPlayer, defenders: 10, converted: []SchlubID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, despawn: true},
+		{name: "player converts what there is", kind: SchlubKindPlayer, defenders: 3, converted: []SchlubID{1, 2, 3}, despawn: true},
+		{name: "monk converts one", kind: SchlubKindMonk, defenders: 5, converted: []SchlubID{1}},
+		{name: "monk converts the last", kind: SchlubKindMonk, defenders: 1, converted: []SchlubID{1}, despawn: true},
+		{name: "warrior slays from the back", kind: SchlubKindWarrior, defenders: 5, slain: []SchlubID{4, 5}},
+		{name: "warrior slays the last", kind: SchlubKindWarrior, defenders: 2, slain: []SchlubID{1, 2}, despawn: true},
+		{name: "vagrant slays one", kind: SchlubKindVagrant, defenders: 5, slain: []SchlubID{5}},
+		{name: "nobody left", kind: SchlubKindWarrior, defenders: 0, despawn: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attacker, defender := numbered(1), numbered(tt.defenders)
+			attacker.OuterKind = tt.kind
+			before := slices.Clone(defender.Schlubs)
+			got := DefaultCombat{}.Resolve(attacker, defender)
+			if !slices.Equal(got.Converted, tt.converted) {
+				t.Errorf("converted %v, want %v", got.Converted, tt.converted)
+			}
+			if !slices.Equal(got.Slain, tt.slain) {
+				t.Errorf("slew %v, want %v", got.Slain, tt.slain)
+			}
+			if got.Despawn != tt.despawn {
+				t.Errorf("despawn = %v, want %v", got.Despawn, tt.despawn)
+			}
+			if !slices.Equal(defender.Schlubs, before) {
+				t.Errorf("resolving changed the defender to %v", defender.Schlubs)
+			}
+		})
+	}
+}
+
+func TestDefaultCombatStats(t *testing.T) {
+	var combat DefaultCombat
+	warrior, monk := numbered(1), numbered(1)
+	warrior.OuterKind, monk.OuterKind = SchlubKindWarrior, SchlubKindMonk
+
+	// A strong warrior against a weak defender slays more, and the other way round slays fewer but never none.
+	warrior.Stats.Strength = RankGold
+	defender := numbered(20)
+	defender.Stats.Endurance = RankBronze
+	if got := combat.Resolve(warrior, defender); len(got.Slain) != 4 {
+		t.Errorf("strong warrior slew %d, want 4", len(got.Slain))
+	}
+	warrior.Stats.Strength = RankWooden
+	defender.Stats.Endurance = RankDiamond
+	if got := combat.Resolve(warrior, defender); len(got.Slain) != 1 {
+		t.Errorf("weak warrior slew %d, want 1", len(got.Slain))
+	}
+
+	// Charisma on both sides decides conversions.
+	monk.Stats.Charisma = RankDiamond
+	defender.Stats.Charisma = RankWooden
+	got := combat.Resolve(monk, defender)
+	if want := ClashCount(1, RankDiamond, RankWooden); len(got.Converted) != want || want < 2 {
+		t.Errorf("charming monk converted %d, want %d", len(got.Converted), want)
+	}
+	if got.Converted[0] != 1 {
+		t.Errorf("charming monk started converting at %v", got.Converted[0])
+	}
+}
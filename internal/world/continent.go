@@ -4,16 +4,26 @@ import (
 	"fmt"
 	"image/color"
 	"math"
+	"slices"
 )
 
 const ContinientFiefSpan = 35                                 // Number of fiefs per row (e.g., 10 for a 10x10 grid)
 const ContinentPixelSpan = ContinientFiefSpan * FiefPixelSpan // Total pixel span of the continent
 
+var continentEdge = math.Nextafter(ContinentPixelSpan, 0) // Furthest a mob can go and still be on the continent.
+
 type Continent struct {
 	Sneed uint
 	Fiefs []*Fief
 	Mobs  Mobs
-	Fate  Fate
+	Items ItemDrops // Items lying around waiting to be picked up.
+
+	Settlements Settlements // Settlements players have founded.
+	Fate        Fate
+
+	pathfinder *Pathfinder    // Made the first time someone needs a path.
+	index      *mobIndex      // Made the first time a mob turns up.
+	owned      map[ID][]*Fief // Fiefs each player owns, kept up to date by SetFiefOwner.
 }
 
 func NewContinent(sneed uint) *Continent {
@@ -25,6 +35,7 @@ func NewContinent(sneed uint) *Continent {
 
 	fate := NewFate(sneed)
 	fiefs := make([]*Fief, totalFiefs)
+	names := make(map[string]bool, totalFiefs)
 	for i := range totalFiefs {
 		x := i % ContinientFiefSpan
 		y := i / ContinientFiefSpan
@@ -32,16 +43,26 @@ func NewContinent(sneed uint) *Continent {
 			panic("failed to create continent: fief coordinates out of bounds")
 		}
 		fiefs[i] = NewFief(&fate, x, y)
+		// Reroll names already taken, but don't try forever.
+		for salt := range nameAttempts {
+			fiefs[i].Name = fiefName(sneed, x, y, salt)
+			if !names[fiefs[i].Name] {
+				break
+			}
+		}
+		names[fiefs[i].Name] = true
 	}
 	if len(fiefs) == 0 || fiefs[0] == nil {
 		panic("failed to create continent: no fiefs generated")
 	}
 
-	return &Continent{
+	c := &Continent{
 		Sneed: sneed,
 		Fate:  fate,
 		Fiefs: fiefs,
 	}
+	c.carveRivers()
+	return c
 }
 
 // NewMob creates a new Mob instance.
@@ -54,32 +75,69 @@ func (c *Continent) NewMob(owner ID, id ID, x, y float64) *Mob {
 		Y:       y,
 		TargetX: x,
 		TargetY: y,
+		Food:    StartingFood,
 	}
 	c.AddMob(mob)
 	return mob
 }
 
+// GetFiefAt returns the fief at the given fief grid coordinates, or nil if they're off the continent.
 func (c *Continent) GetFiefAt(x, y int) *Fief {
-	fiefNum := len(c.Fiefs)
-
 	// Determine 1-d idx based on x and y coordinates
-	if x < 0 || y < 0 || x >= fiefNum || y >= fiefNum {
+	if x < 0 || y < 0 || x >= ContinientFiefSpan || y >= ContinientFiefSpan {
 		// Abso-lute-ly out of bounds
 		return nil
 	}
 
-	idx := x + y*fiefNum
-	if idx < 0 || idx >= len(c.Fiefs) {
+	idx := x + y*ContinientFiefSpan
+	if idx >= len(c.Fiefs) {
 		// Fief-ly out of bounds
 		return nil
 	}
 	return c.Fiefs[idx]
 }
 
+// FiefCoords returns the fief grid coordinates containing the given pixel coordinates, and false if they're off the continent.
+func FiefCoords(x, y float64) (int, int, bool) {
+	// Written so NaN counts as off the continent too.
+	if !(x >= 0 && y >= 0 && x < ContinentPixelSpan && y < ContinentPixelSpan) {
+		return 0, 0, false
+	}
+	return int(x / FiefPixelSpan), int(y / FiefPixelSpan), true
+}
+
+// TileCoords returns the tile grid coordinates across the whole continent containing the given pixel coordinates, and false if they're off the continent.
+func TileCoords(x, y float64) (int, int, bool) {
+	if !(x >= 0 && y >= 0 && x < ContinentPixelSpan && y < ContinentPixelSpan) {
+		return 0, 0, false
+	}
+	return int(x / TileSize), int(y / TileSize), true
+}
+
+// ClampToContinent returns the nearest pixel coordinates to the given ones that are on the continent.
+func ClampToContinent(x, y float64) (float64, float64) {
+	return clamp64(x, 0, continentEdge), clamp64(y, 0, continentEdge)
+}
+
+// FiefIndex returns the index of the fief containing the given pixel coordinates, or -1 if they're off the continent.
+func (c *Continent) FiefIndex(x, y float64) int {
+	fiefX, fiefY, ok := FiefCoords(x, y)
+	if !ok {
+		return -1
+	}
+	idx := fiefX + fiefY*ContinientFiefSpan
+	if idx >= len(c.Fiefs) {
+		return -1
+	}
+	return idx
+}
+
+// GetContainingFief returns the fief containing the given pixel coordinates, or nil if they're off the continent.
 func (c *Continent) GetContainingFief(x, y float64) *Fief {
-	// Translate pixel coordinates to fief grid coordinates
-	fiefX := int(math.Floor(x / float64(ContinentPixelSpan)))
-	fiefY := int(math.Floor(y / float64(ContinentPixelSpan)))
+	fiefX, fiefY, ok := FiefCoords(x, y)
+	if !ok {
+		return nil
+	}
 	return c.GetFiefAt(fiefX, fiefY)
 }
 
@@ -89,13 +147,12 @@ func (c *Continent) GetVisibleFiefs(mob *Mob) []*Fief {
 	}
 
 	// Slice the fief grid based on the mob's vision radius
-	fiefNum := len(c.Fiefs)
 	fiefPixelSpan := float64(FiefPixelSpan)
 	visionRadius := mob.Vision()
 	minX := max(math.Floor((mob.X-visionRadius)/fiefPixelSpan), 0)
 	minY := max(math.Floor((mob.Y-visionRadius)/fiefPixelSpan), 0)
-	maxX := min(math.Ceil((mob.X+visionRadius)/fiefPixelSpan), float64(fiefNum-1))
-	maxY := min(math.Ceil((mob.Y+visionRadius)/fiefPixelSpan), float64(fiefNum-1))
+	maxX := min(math.Floor((mob.X+visionRadius)/fiefPixelSpan), ContinientFiefSpan-1)
+	maxY := min(math.Floor((mob.Y+visionRadius)/fiefPixelSpan), ContinientFiefSpan-1)
 
 	visibleFiefs := []*Fief{}
 	for x := minX; x <= maxX; x++ {
@@ -121,14 +178,7 @@ func (c *Continent) GetVisibleMobs(mob *Mob) Mobs {
 	if mob == nil {
 		return nil
 	}
-	visibleMobs := Mobs{}
-	for _, fief := range c.GetVisibleFiefs(mob) {
-		if fief == nil {
-			continue
-		}
-		visibleMobs = append(visibleMobs, fief.Mobs.FindVisible(mob.ID)...)
-	}
-	return visibleMobs
+	return c.VisibleMobs(mob)
 }
 
 func (c *Continent) AddMob(mob *Mob) {
@@ -144,6 +194,79 @@ func (c *Continent) AddMob(mob *Mob) {
 
 	c.Mobs.Add(mob)
 	fief.Mobs.Add(mob)
+	c.mobIndex().add(mob)
+}
+
+// ClearMobs removes every mob from the continent.
+func (c *Continent) ClearMobs() {
+	c.Mobs = nil
+	c.index = nil
+	for _, fief := range c.Fiefs {
+		fief.Mobs = nil
+	}
+}
+
+// OwnedFiefs returns the fiefs claimed by the given owner. Barbarians don't own anything. The slice belongs to the continent and must not be modified.
+func (c *Continent) OwnedFiefs(owner ID) []*Fief {
+	if owner == 0 {
+		return nil
+	}
+	return c.owned[owner]
+}
+
+// SetFiefOwner hands the fief over to the given owner, or to nobody if the owner is 0.
+func (c *Continent) SetFiefOwner(fief *Fief, owner ID) {
+	if fief.OwnerID == owner {
+		return
+	}
+	if fief.OwnerID != 0 {
+		c.owned[fief.OwnerID] = slices.DeleteFunc(c.owned[fief.OwnerID], func(other *Fief) bool {
+			return other == fief
+		})
+	}
+	fief.OwnerID = owner
+	if owner != 0 {
+		if c.owned == nil {
+			c.owned = make(map[ID][]*Fief)
+		}
+		c.owned[owner] = append(c.owned[owner], fief)
+	}
+}
+
+// FindResource returns the resource with the given ID along with the fief it's in.
+func (c *Continent) FindResource(id ID) (*Resource, *Fief) {
+	for _, fief := range c.Fiefs {
+		if res := fief.Resources.FindByID(id); res != nil {
+			return res, fief
+		}
+	}
+	return nil, nil
+}
+
+// ResourceCount returns how many resources there are across every fief.
+func (c *Continent) ResourceCount() int {
+	count := 0
+	for _, fief := range c.Fiefs {
+		count += len(fief.Resources)
+	}
+	return count
+}
+
+// ClearResources removes every resource from the continent.
+func (c *Continent) ClearResources() {
+	for _, fief := range c.Fiefs {
+		fief.Resources = nil
+	}
+}
+
+// ClearClaims forgets who owns or is claiming every fief.
+func (c *Continent) ClearClaims() {
+	for _, fief := range c.Fiefs {
+		fief.OwnerID = 0
+		fief.Claimant = 0
+		fief.Claim = 0
+	}
+	c.owned = nil
 }
 
 func (c *Continent) RemoveMob(mob *Mob) {
@@ -151,11 +274,11 @@ func (c *Continent) RemoveMob(mob *Mob) {
 		return
 	}
 
-	fief := c.GetContainingFief(mob.X, mob.Y)
-	if fief != nil {
+	if fief := c.GetContainingFief(mob.X, mob.Y); fief != nil {
 		fief.Mobs.Remove(mob)
-		c.Mobs.Remove(mob)
 	}
+	c.Mobs.Remove(mob)
+	c.mobIndex().remove(mob)
 }
 
 func (c *Continent) MoveMob(mob *Mob, x, y float64) {
@@ -163,20 +286,29 @@ func (c *Continent) MoveMob(mob *Mob, x, y float64) {
 		return
 	}
 
-	fiefs := c.GetVisibleFiefs(mob)
-	for _, fief := range fiefs {
-		for mobInFief := range fief.Mobs {
-			if mobInFief != mob.ID && mob.Intersects(c.Mobs.FindByID(mobInFief)) {
-				// If the mob intersects with another mob in the same fief, do not move
-				mob.TargetX = mob.X
-				mob.TargetY = mob.Y
-				return
-			}
-		}
+	if len(c.IntersectingMobs(mob)) > 0 {
+		// If the mob intersects with another mob, do not move
+		mob.Stop()
+		return
+	}
+
+	newX, newY := ClampToContinent(x, y)
+	// The terrain underfoot decides whether and how far the mob gets.
+	newX, newY, ok := c.limitMove(mob, newX, newY)
+	if !ok {
+		mob.Stop()
+		return
+	}
+	c.PlaceMob(mob, newX, newY)
+}
+
+// PlaceMob puts the mob at the given pixel coordinates, keeping track of the fief it's in. Unlike MoveMob, nothing stands in its way, so it's for positions the server has already settled on.
+func (c *Continent) PlaceMob(mob *Mob, x, y float64) {
+	if mob == nil {
+		return
 	}
 
-	newX := clamp64(x, 0, float64(ContinentPixelSpan))
-	newY := clamp64(y, 0, float64(ContinentPixelSpan))
+	newX, newY := ClampToContinent(x, y)
 	newFief := c.GetContainingFief(newX, newY)
 	if newFief == nil {
 		return
@@ -185,6 +317,7 @@ func (c *Continent) MoveMob(mob *Mob, x, y float64) {
 	currentFief := c.GetContainingFief(mob.X, mob.Y)
 	mob.X = newX
 	mob.Y = newY
+	c.mobIndex().refile(mob)
 
 	if currentFief == newFief {
 		// Mob is already in the correct fief, no need to move
@@ -0,0 +1,189 @@
+package world
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// offContinent are pixel coordinates that aren't on any fief.
+var offContinent = [][2]float64{
+	{-1e-9, 0},
+	{0, -1e-9},
+	{-1, -1},
+	{ContinentPixelSpan, 0},
+	{0, ContinentPixelSpan},
+	{ContinentPixelSpan, ContinentPixelSpan},
+	{math.NaN(), 0},
+	{0, math.NaN()},
+	{math.Inf(1), 0},
+	{0, math.Inf(-1)},
+}
+
+func TestFiefCoords(t *testing.T) {
+	span := float64(FiefPixelSpan)
+	for fy := range ContinientFiefSpan {
+		for fx := range ContinientFiefSpan {
+			left, top := float64(fx)*span, float64(fy)*span
+			// Every corner of the fief, its middle, and right up against its far edges.
+			for _, at := range [][2]float64{
+				{left, top},
+				{left + span/2, top + span/2},
+				{left + span - 1e-6, top},
+				{left, top + span - 1e-6},
+				{left + span - 1e-6, top + span - 1e-6},
+			} {
+				x, y, ok := FiefCoords(at[0], at[1])
+				if !ok || x != fx || y != fy {
+					t.Fatalf("FiefCoords(%v, %v) = %d, %d, %v, want %d, %d", at[0], at[1], x, y, ok, fx, fy)
+				}
+			}
+		}
+	}
+	for _, at := range offContinent {
+		if _, _, ok := FiefCoords(at[0], at[1]); ok {
+			t.Errorf("FiefCoords(%v, %v) is on the continent", at[0], at[1])
+		}
+	}
+}
+
+func TestTileCoords(t *testing.T) {
+	for ty := range continentGrid {
+		for tx := range continentGrid {
+			left, top := float64(tx*TileSize), float64(ty*TileSize)
+			for _, at := range [][2]float64{
+				{left, top},
+				{left + TileSize - 1e-6, top + TileSize - 1e-6},
+			} {
+				x, y, ok := TileCoords(at[0], at[1])
+				if !ok || x != tx || y != ty {
+					t.Fatalf("TileCoords(%v, %v) = %d, %d, %v, want %d, %d", at[0], at[1], x, y, ok, tx, ty)
+				}
+			}
+		}
+	}
+	for _, at := range offContinent {
+		if _, _, ok := TileCoords(at[0], at[1]); ok {
+			t.Errorf("TileCoords(%v, %v) is on the continent", at[0], at[1])
+		}
+	}
+}
+
+func TestContinentLookups(t *testing.T) {
+	c := NewContinent(1)
+	span := float64(FiefPixelSpan)
+	for fy := range ContinientFiefSpan {
+		for fx := range ContinientFiefSpan {
+			idx := fy*ContinientFiefSpan + fx
+			fief := c.GetFiefAt(fx, fy)
+			if fief != c.Fiefs[idx] {
+				t.Fatalf("GetFiefAt(%d, %d) isn't fief %d", fx, fy, idx)
+			}
+			if fief.X != float64(fx)*span || fief.Y != float64(fy)*span {
+				t.Fatalf("fief %d is at %v, %v", idx, fief.X, fief.Y)
+			}
+			for _, at := range [][2]float64{{0, 0}, {span / 2, span / 2}, {span - 1e-6, span - 1e-6}} {
+				x, y := fief.X+at[0], fief.Y+at[1]
+				if got := c.GetContainingFief(x, y); got != fief {
+					t.Fatalf("GetContainingFief(%v, %v) isn't fief %d", x, y, idx)
+				}
+				if got := c.FiefIndex(x, y); got != idx {
+					t.Fatalf("FiefIndex(%v, %v) = %d, want %d", x, y, got, idx)
+				}
+			}
+		}
+	}
+	for ty := range continentGrid {
+		for tx := range continentGrid {
+			x, y := (float64(tx)+0.5)*TileSize, (float64(ty)+0.5)*TileSize
+			fief := c.GetContainingFief(x, y)
+			if want := &fief.Tiles[(ty%FiefSize)*FiefSize+tx%FiefSize]; c.TileAt(x, y) != want || c.tileAtGrid(tx, ty) != want {
+				t.Fatalf("tile %d, %d doesn't line up", tx, ty)
+			}
+		}
+	}
+	for _, at := range [][2]int{{-1, 0}, {0, -1}, {ContinientFiefSpan, 0}, {0, ContinientFiefSpan}, {len(c.Fiefs), 0}} {
+		if c.GetFiefAt(at[0], at[1]) != nil {
+			t.Errorf("GetFiefAt(%d, %d) found a fief", at[0], at[1])
+		}
+	}
+	for _, at := range offContinent {
+		if c.GetContainingFief(at[0], at[1]) != nil || c.FiefIndex(at[0], at[1]) != -1 || c.TileAt(at[0], at[1]) != nil {
+			t.Errorf("%v, %v found something off the continent", at[0], at[1])
+		}
+	}
+}
+
+func TestClampToContinent(t *testing.T) {
+	c := NewContinent(1)
+	c.ClearMobs()
+	for i, at := range [][2]float64{
+		{-100, -100},
+		{ContinentPixelSpan, ContinentPixelSpan},
+		{ContinentPixelSpan + 100, 5},
+		{5, ContinentPixelSpan},
+	} {
+		x, y := ClampToContinent(at[0], at[1])
+		if c.GetContainingFief(x, y) == nil {
+			t.Errorf("ClampToContinent(%v, %v) = %v, %v, which is off the continent", at[0], at[1], x, y)
+		}
+		if mob := c.NewMob(1, ID(i+1), x, y); c.FindMob(mob.ID) != mob {
+			t.Errorf("mob at %v, %v wasn't added", x, y)
+		}
+	}
+}
+
+// checkFiefMobs fails if any fief has a mob that isn't in it, or the fiefs don't have every mob between them.
+func checkFiefMobs(t *testing.T, c *Continent) {
+	t.Helper()
+	total := 0
+	for i, fief := range c.Fiefs {
+		total += len(fief.Mobs)
+		for _, mob := range fief.Mobs {
+			if c.FindMob(mob.ID) != mob {
+				t.Fatalf("fief %d has mob %d, which isn't on the continent", i, mob.ID)
+			}
+			if got := c.FiefIndex(mob.X, mob.Y); got != i {
+				t.Fatalf("fief %d has mob %d, which is in fief %d", i, mob.ID, got)
+			}
+		}
+	}
+	if total != len(c.Mobs) {
+		t.Fatalf("fiefs have %d mobs between them, continent has %d", total, len(c.Mobs))
+	}
+}
+
+func TestFiefMobsFollowMoves(t *testing.T) {
+	c := NewContinent(1)
+	c.ClearMobs()
+	// Nothing in the way, so mobs go wherever they're told.
+	paint(c, 0, 0, continentGrid, continentGrid, TerrainGrass)
+	rng := rand.New(rand.NewSource(1))
+	for i := range 300 {
+		c.NewMob(1, ID(i+1), rng.Float64()*ContinentPixelSpan, rng.Float64()*ContinentPixelSpan)
+	}
+	checkFiefMobs(t, c)
+	for range 50 {
+		for _, mob := range slices.Clone(c.Mobs) {
+			c.MoveMob(mob, mob.X+rng.Float64()*400-200, mob.Y+rng.Float64()*400-200)
+			if !slices.Contains(c.GetVisibleFiefs(mob), c.GetContainingFief(mob.X, mob.Y)) {
+				t.Fatalf("mob %d can't see the fief it's in", mob.ID)
+			}
+		}
+		checkFiefMobs(t, c)
+		c.RemoveMob(c.Mobs[rng.Intn(len(c.Mobs))])
+		checkFiefMobs(t, c)
+	}
+
+	// Shoving a mob off the far corner leaves it in the last fief.
+	mob := c.Mobs[0]
+	c.ClearMobs()
+	mob.X, mob.Y = ContinentPixelSpan-2, ContinentPixelSpan-2
+	c.AddMob(mob)
+	c.MoveMob(mob, ContinentPixelSpan+100, ContinentPixelSpan+100)
+	if got := c.FiefIndex(mob.X, mob.Y); got != len(c.Fiefs)-1 {
+		t.Errorf("mob ended up at %v, %v in fief %d", mob.X, mob.Y, got)
+	}
+	checkFiefMobs(t, c)
+}
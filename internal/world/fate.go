@@ -5,12 +5,14 @@ import (
 	"math/rand"
 
 	"github.com/KEINOS/go-noise"
+	"github.com/ojrac/opensimplex-go"
 )
 
 type Fate struct {
 	noise.Generator
 	NumGen    *rand.Rand
 	certainty float64
+	simplex   opensimplex.Noise // Same noise as Generator, but it doesn't rebuild itself every call.
 }
 
 func NewFate(sneed uint) Fate {
@@ -23,6 +25,7 @@ func NewFate(sneed uint) Fate {
 		Generator: generator,
 		NumGen:    rand.New(rand.NewSource(int64(sneed))),
 		certainty: 200,
+		simplex:   opensimplex.New(int64(sneed)),
 	}
 }
 
@@ -31,6 +34,13 @@ func (f *Fate) Determine(values ...float64) float64 {
 		return f.Eval64(0.0)
 	}
 
+	switch len(values) {
+	case 2:
+		return f.simplex.Eval2(values[0]/f.certainty, values[1]/f.certainty)
+	case 3:
+		return f.simplex.Eval3(values[0]/f.certainty, values[1]/f.certainty, values[2]/f.certainty)
+	}
+
 	smoothed := make([]float64, len(values))
 	for i, v := range values {
 		smoothed[i] = v / f.certainty
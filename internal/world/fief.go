@@ -3,12 +3,19 @@ package world
 const FiefSize = 16                       // Number of tiles per fief row (e.g., 64x64)
 const FiefTiles = FiefSize * FiefSize     // Total number of tiles in a fief
 const FiefPixelSpan = FiefSize * TileSize // Total pixel span of a fief
+const FiefClaimRounds = 10                // Rounds a player has to hold a fief unopposed to claim it
 
 type Fief struct {
 	X, Y      float64
 	Name      string
 	Mobs      Mobs
+	Resources Resources
 	Tiles     []Tile
+	Terrain   Terrain // Terrain most of the fief's tiles have.
+	Elevation float64 // Average elevation of the fief's tiles.
+	OwnerID   ID      // Player who has claimed the fief, if anyone.
+	Claimant  ID      // Player working on claiming the fief.
+	Claim     int     // Rounds the claimant has held the fief for.
 	modifiers []Modifier
 }
 
@@ -24,7 +31,7 @@ func NewFief(fate *Fate, x, y int) *Fief {
 		tiles[i] = NewTile(fate, tileX, tileY)
 	}
 
-	return &Fief{
+	fief := &Fief{
 		X:         fiefX,
 		Y:         fiefY,
 		Name:      "Fief",
@@ -32,6 +39,108 @@ func NewFief(fate *Fate, x, y int) *Fief {
 		Tiles:     tiles,
 		modifiers: []Modifier{},
 	}
+	fief.survey()
+	return fief
+}
+
+// survey works out the fief's terrain and elevation from its tiles, and the modifier that comes with the terrain.
+func (f *Fief) survey() {
+	f.Terrain = f.DominantTerrain()
+	f.Elevation = f.AverageElevation()
+	f.modifiers = f.modifiers[:0]
+	if modifier, ok := terrainModifier(f.Terrain); ok {
+		f.AddModifier(modifier)
+	}
+}
+
+// terrainModifier returns the modifier a fief gets for being mostly the given terrain, if any.
+func terrainModifier(terrain Terrain) (Modifier, bool) {
+	switch terrain {
+	case TerrainWater:
+		return Modifier{Stats: Stats{Agility: -1}, Reason: "Marshy"}, true
+	case TerrainRocks, TerrainRockyDirt, TerrainRockySand, TerrainGrassyRocks:
+		return Modifier{Stats: Stats{Endurance: 1}, Reason: "Rocky"}, true
+	case TerrainGrass, TerrainGrassyDirt:
+		return Modifier{Stats: Stats{Luck: 1}, Reason: "Lush"}, true
+	case TerrainSand, TerrainSandyDirt:
+		return Modifier{Stats: Stats{Endurance: -1}, Reason: "Arid"}, true
+	}
+	return Modifier{}, false
+}
+
+// DominantTerrain returns the terrain that most of the fief's tiles have.
+func (f *Fief) DominantTerrain() Terrain {
+	var counts [TerrainCount]int
+	dominant := TerrainNone
+	for _, tile := range f.Tiles {
+		if tile.Terrain < 0 || tile.Terrain >= TerrainCount {
+			continue
+		}
+		counts[tile.Terrain]++
+		if counts[tile.Terrain] > counts[dominant] {
+			dominant = tile.Terrain
+		}
+	}
+	return dominant
+}
+
+// AverageElevation returns the average elevation of the fief's tiles.
+func (f *Fief) AverageElevation() float64 {
+	if len(f.Tiles) == 0 {
+		return 0
+	}
+	var total float64
+	for _, tile := range f.Tiles {
+		total += tile.Elevation
+	}
+	return total / float64(len(f.Tiles))
+}
+
+// Region describes the lay of the fief's land, e.g. "Grassy Rocks highlands".
+func (f *Fief) Region() string {
+	switch {
+	case f.Elevation < HillLevel:
+		return f.Terrain.String() + " lowlands"
+	case f.Elevation < PeakLevel:
+		return f.Terrain.String() + " hills"
+	default:
+		return f.Terrain.String() + " highlands"
+	}
+}
+
+// Modifiers returns the modifiers applied to every mob in the fief.
+func (f *Fief) Modifiers() []Modifier {
+	return f.modifiers
+}
+
+// AddModifier adds a modifier that applies to every mob in the fief.
+func (f *Fief) AddModifier(modifier Modifier) {
+	f.modifiers = append(f.modifiers, modifier)
+}
+
+// Hold advances the claim on the fief by whoever is holding it this round. Nobody or more than one player holding it is given as 0, which lets the claim lapse. It returns true once the holder has held it long enough to take it, at which point the caller hands it over with Continent.SetFiefOwner.
+func (f *Fief) Hold(holder ID) bool {
+	if holder == 0 || holder == f.OwnerID {
+		f.Claimant = 0
+		f.Claim = 0
+		return false
+	}
+	if holder != f.Claimant {
+		f.Claimant = holder
+		f.Claim = 0
+	}
+	f.Claim++
+	if f.Claim < FiefClaimRounds {
+		return false
+	}
+	f.Claimant = 0
+	f.Claim = 0
+	return true
+}
+
+// TilePosition returns the top-left pixel of the tile at the given index.
+func (f *Fief) TilePosition(i int) (float64, float64) {
+	return f.X + float64((i%FiefSize)*TileSize), f.Y + float64((i/FiefSize)*TileSize)
 }
 
 func (f *Fief) GetTileAt(x, y float64) *Tile {
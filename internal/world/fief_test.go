@@ -0,0 +1,114 @@
+package world
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestFiefHold(t *testing.T) {
+	// hold repeats a holder for so many rounds.
+	hold := func(holder ID, rounds int) []ID {
+		return slices.Repeat([]ID{holder}, rounds)
+	}
+	tests := []struct {
+		name     string
+		owner    ID
+		rounds   []ID
+		taken    int // Round the fief is taken on, or -1 if never.
+		claimant ID
+		claim    int
+	}{
+		{name: "held long enough", rounds: hold(1, FiefClaimRounds), taken: FiefClaimRounds - 1},
+		{name: "not quite", rounds: hold(1, FiefClaimRounds-1), taken: -1, claimant: 1, claim: FiefClaimRounds - 1},
+		{name: "rival starts over", rounds: append(hold(1, FiefClaimRounds-1), 2), taken: -1, claimant: 2, claim: 1},
+		{name: "rival takes it after starting over", rounds: append(hold(1, FiefClaimRounds-1), hold(2, FiefClaimRounds)...), taken: 2*FiefClaimRounds - 2},
+		{name: "nobody lets it lapse", rounds: append(hold(1, FiefClaimRounds-1), 0), taken: -1},
+		{name: "lapse then start again", rounds: append(append(hold(1, FiefClaimRounds-1), 0), hold(1, FiefClaimRounds-1)...), taken: -1, claimant: 1, claim: FiefClaimRounds - 1},
+		{name: "owner holding it is nothing new", owner: 1, rounds: hold(1, 2*FiefClaimRounds), taken: -1},
+		{name: "owner back in time stops a claim", owner: 1, rounds: append(hold(2, FiefClaimRounds-1), 1), taken: -1},
+		{name: "taken from its owner", owner: 1, rounds: hold(2, FiefClaimRounds), taken: FiefClaimRounds - 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fief := &Fief{OwnerID: tt.owner}
+			taken := -1
+			for round, holder := range tt.rounds {
+				if fief.Hold(holder) {
+					if taken != -1 {
+						t.Fatalf("taken again on round %d", round)
+					}
+					taken = round
+					fief.OwnerID = holder
+				}
+			}
+			if taken != tt.taken {
+				t.Errorf("taken on round %d, want %d", taken, tt.taken)
+			}
+			if fief.Claimant != tt.claimant || fief.Claim != tt.claim {
+				t.Errorf("claim is %d rounds by %d, want %d rounds by %d", fief.Claim, fief.Claimant, tt.claim, tt.claimant)
+			}
+		})
+	}
+}
+
+func TestSetFiefOwner(t *testing.T) {
+	c := NewContinent(1)
+	a, b := c.Fiefs[0], c.Fiefs[1]
+	c.SetFiefOwner(a, 1)
+	c.SetFiefOwner(b, 1)
+	c.SetFiefOwner(b, 1)
+	if got := c.OwnedFiefs(1); len(got) != 2 || got[0] != a || got[1] != b {
+		t.Fatalf("player 1 owns %d fiefs, want 2", len(got))
+	}
+	c.SetFiefOwner(a, 2)
+	if got := c.OwnedFiefs(1); len(got) != 1 || got[0] != b {
+		t.Errorf("player 1 still owns %d fiefs after losing one", len(got))
+	}
+	if got := c.OwnedFiefs(2); len(got) != 1 || got[0] != a || a.OwnerID != 2 {
+		t.Errorf("player 2 didn't get the fief")
+	}
+	c.SetFiefOwner(b, 0)
+	if got := c.OwnedFiefs(1); len(got) != 0 || b.OwnerID != 0 {
+		t.Errorf("player 1 still owns %d fiefs after releasing them", len(got))
+	}
+	if c.OwnedFiefs(0) != nil {
+		t.Errorf("barbarians own something")
+	}
+	c.ClearClaims()
+	if len(c.OwnedFiefs(2)) != 0 || a.OwnerID != 0 {
+		t.Errorf("claims weren't cleared")
+	}
+}
+
+func TestOwnedFiefModifiers(t *testing.T) {
+	marsh := func() *Fief { return &Fief{modifiers: []Modifier{{Stats: Stats{Agility: -1}, Reason: "Marshy"}}} }
+	rocks := func() *Fief { return &Fief{modifiers: []Modifier{{Stats: Stats{Endurance: 1}, Reason: "Rocky"}}} }
+	lush := func() *Fief { return &Fief{modifiers: []Modifier{{Stats: Stats{Luck: 1}, Reason: "Lush"}}} }
+	plain := &Fief{}
+	vagrant := []SchlubID{schlub(SchlubKindVagrant, adult, ItemNone)}
+	marshy, rocky, lucky := Modifier{Stats: Stats{Agility: -1}}, Modifier{Stats: Stats{Endurance: 1}}, Modifier{Stats: Stats{Luck: 1}}
+
+	tests := []struct {
+		name  string
+		in    *Fief
+		owned []*Fief
+		want  []Modifier // Modifiers the mob should end up with.
+	}{
+		{name: "nothing owned", in: plain},
+		{name: "one rocky fief", in: plain, owned: []*Fief{rocks()}, want: []Modifier{rocky}},
+		{name: "many rocky fiefs count once", in: plain, owned: []*Fief{rocks(), rocks(), rocks(), rocks()}, want: []Modifier{rocky}},
+		{name: "many marshes count once", in: plain, owned: []*Fief{marsh(), marsh(), marsh(), marsh(), marsh()}, want: []Modifier{marshy}},
+		{name: "standing in an owned marsh", in: marsh(), owned: []*Fief{marsh(), marsh()}, want: []Modifier{marshy}},
+		{name: "different kinds add up", in: plain, owned: []*Fief{rocks(), lush(), rocks(), lush()}, want: []Modifier{rocky, lucky}},
+		{name: "outside the territory", in: nil, owned: []*Fief{lush(), lush()}, want: []Modifier{lucky}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mob := &Mob{Schlubs: vagrant}
+			mob.RefreshStats(tt.in, tt.owned...)
+			if want := StatsFor(vagrant, tt.want...); *mob.Stats != *want {
+				t.Errorf("stats = %+v, want %+v", *mob.Stats, *want)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package world
+
+const (
+	SchlubsPerFood = 10 // Schlubs one food feeds for an upkeep round.
+	FoodPerSchlub  = 5  // Food each schlub can carry.
+	FoodPerCaravan = 25 // Food each caravan can carry, on top of what it'd carry as a schlub.
+	FoodBase       = 20 // Food any mob can carry, however small.
+	StartingFood   = 20 // Food new mobs start out with.
+)
+
+// Upkeep returns how much food the mob eats every upkeep round.
+func (m *Mob) Upkeep() int {
+	if len(m.Schlubs) == 0 {
+		return 0
+	}
+	return (len(m.Schlubs) + SchlubsPerFood - 1) / SchlubsPerFood
+}
+
+// FoodCapacity returns how much food the mob can carry.
+func (m *Mob) FoodCapacity() int {
+	capacity := FoodBase + len(m.Schlubs)*FoodPerSchlub
+	for _, schlub := range m.Schlubs {
+		switch SchlubID(schlub.KindID()) {
+		case SchlubKindCaravanVagrant, SchlubKindCaravanMonk, SchlubKindCaravanWarrior:
+			capacity += FoodPerCaravan
+		}
+	}
+	return capacity
+}
+
+// StoreFood adds as much of the food as the mob can carry and returns how much it took.
+func (m *Mob) StoreFood(food int) int {
+	food = max(0, min(food, m.FoodCapacity()-m.Food))
+	m.Food += food
+	return food
+}
+
+// Eat has the mob eat its upkeep and returns how much food it was short. A schlub starves for every food short.
+func (m *Mob) Eat() int {
+	upkeep := m.Upkeep()
+	if m.Food >= upkeep {
+		m.Food -= upkeep
+		return 0
+	}
+	short := upkeep - m.Food
+	m.Food = 0
+	return short
+}
@@ -0,0 +1,73 @@
+package world
+
+import "testing"
+
+// mobOf returns a mob of count schlubs of the given kind.
+func mobOf(kind SchlubID, count int) *Mob {
+	mob := &Mob{}
+	for range count {
+		mob.AddSchlub(schlub(kind, adult, ItemNone))
+	}
+	return mob
+}
+
+func TestUpkeep(t *testing.T) {
+	for _, tt := range []struct {
+		schlubs, want int
+	}{
+		{0, 0},
+		{1, 1},
+		{SchlubsPerFood, 1},
+		{SchlubsPerFood + 1, 2},
+		{10 * SchlubsPerFood, 10},
+	} {
+		if got := mobOf(SchlubKindVagrant, tt.schlubs).Upkeep(); got != tt.want {
+			t.Errorf("%d schlubs eat %d, want %d", tt.schlubs, got, tt.want)
+		}
+	}
+}
+
+func TestFoodCapacity(t *testing.T) {
+	if got := mobOf(SchlubKindVagrant, 0).FoodCapacity(); got != FoodBase {
+		t.Errorf("empty mob carries %d, want %d", got, FoodBase)
+	}
+	if got, want := mobOf(SchlubKindVagrant, 4).FoodCapacity(), FoodBase+4*FoodPerSchlub; got != want {
+		t.Errorf("4 vagrants carry %d, want %d", got, want)
+	}
+	for _, kind := range []SchlubID{SchlubKindCaravanVagrant, SchlubKindCaravanMonk, SchlubKindCaravanWarrior} {
+		if got, want := mobOf(kind, 2).FoodCapacity(), FoodBase+2*(FoodPerSchlub+FoodPerCaravan); got != want {
+			t.Errorf("2 caravans of kind %d carry %d, want %d", kind, got, want)
+		}
+	}
+}
+
+func TestStoreFood(t *testing.T) {
+	mob := mobOf(SchlubKindVagrant, 1)
+	capacity := mob.FoodCapacity()
+	if took := mob.StoreFood(10); took != 10 || mob.Food != 10 {
+		t.Errorf("took %d of 10, has %d", took, mob.Food)
+	}
+	if took := mob.StoreFood(capacity); took != capacity-10 || mob.Food != capacity {
+		t.Errorf("took %d when full up, has %d of %d", took, mob.Food, capacity)
+	}
+	if took := mob.StoreFood(5); took != 0 || mob.Food != capacity {
+		t.Errorf("took %d when already full", took)
+	}
+	if took := mob.StoreFood(-5); took != 0 || mob.Food != capacity {
+		t.Errorf("took %d from nothing", took)
+	}
+}
+
+func TestEat(t *testing.T) {
+	mob := mobOf(SchlubKindVagrant, 3*SchlubsPerFood)
+	mob.Food = 4
+	if short := mob.Eat(); short != 0 || mob.Food != 1 {
+		t.Errorf("ate with %d short, %d left", short, mob.Food)
+	}
+	if short := mob.Eat(); short != 2 || mob.Food != 0 {
+		t.Errorf("ate with %d short, %d left, want 2 short", short, mob.Food)
+	}
+	if short := mob.Eat(); short != 3 {
+		t.Errorf("ate nothing with %d short, want 3", short)
+	}
+}
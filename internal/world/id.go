@@ -29,11 +29,11 @@ func (gen *IDGenerator) Reset() {
 }
 
 /*
-9-bit family ID (511)
-10-bit constituents ID (1023)
-3-bit kind ID (7)
-5-bit item ID (31)
-5-bit age (31)
+9-bit family ID (511), bits 23-31
+10-bit constituents ID (1023), bits 13-22
+3-bit kind ID (7), bits 10-12
+5-bit item ID (31), bits 5-9
+5-bit age (31), bits 0-4
 */
 
 type SchlubID int
@@ -61,29 +61,29 @@ func (s SchlubID) String() string {
 
 // NextFamily gets the next family ID from the current schlub ID. It returns a new SchlubID with the family incremented from the former and all other fields zeroed out.
 func (s SchlubID) NextFamily() SchlubID {
-	familyID := int(s) >> 22
+	familyID := (int(s) >> 23) & 0x1FF
 	familyID++
 	if familyID > 511 {
 		familyID = 0
 	}
-	return SchlubID(familyID << 22)
+	return SchlubID(familyID << 23)
 }
 
 func (s SchlubID) FamilyID() int {
 	// Extract the 9-bit family ID from the SchlubID
-	return (int(s) >> 22) & 0x1FF
+	return (int(s) >> 23) & 0x1FF
 }
 
 func (s SchlubID) NextSchlub() SchlubID {
 	// Keep the kind id as well.
-	kindID := (int(s) >> 9) & 0x7
+	kindID := (int(s) >> 10) & 0x7
 	// Keep the 9-bit family id and increment the 10-bit schlub id
-	schlubID := (int(s) >> 12) & 0x3FF
+	schlubID := (int(s) >> 13) & 0x3FF
 	schlubID++
 	if schlubID > 1023 {
 		schlubID = 0
 	}
-	return SchlubID((int(s) & 0xFFFFF000) | (schlubID << 12) | (kindID << 9))
+	return SchlubID((int(s) & 0xFF800000) | (schlubID << 13) | (kindID << 10))
 }
 
 // NextSchlubs returns count new schlub IDs from the start of the schlub ID. If the original schlub ID should change, assign it to the last schlub returned.
@@ -91,7 +91,7 @@ func (s SchlubID) NextSchlubs(count int) []SchlubID {
 	var schlubs []SchlubID
 	s2 := s
 	for range count {
-		s2 = s.NextSchlub()
+		s2 = s2.NextSchlub()
 		schlubs = append(schlubs, s2)
 	}
 	return schlubs
@@ -99,7 +99,7 @@ func (s SchlubID) NextSchlubs(count int) []SchlubID {
 
 func (s SchlubID) SchlubID() int {
 	// Extract the 10-bit schlub ID from the SchlubID
-	return (int(s) >> 12) & 0x3FF
+	return (int(s) >> 13) & 0x3FF
 }
 
 func (s SchlubID) BitsAsString() string {
@@ -108,19 +108,19 @@ func (s SchlubID) BitsAsString() string {
 }
 
 func (s SchlubID) KindID() int {
-	return (int(s) >> 9) & 0x7
+	return (int(s) >> 10) & 0x7
 }
 func (s *SchlubID) SetKindID(kind int) {
 	// Set the 3-bit kind ID in the SchlubID
 	if kind < 0 || kind > 6 {
 		panic("kind must be between 0 and 6")
 	}
-	*s = SchlubID((int(*s) & 0xFFFFF1FF) | (kind << 9))
+	*s = SchlubID((int(*s) &^ 0x1C00) | (kind << 10))
 }
 
 func (s SchlubID) ItemID() int {
 	// Extract the 5-bit item ID from the SchlubID
-	return (int(s) >> 4) & 0x1F
+	return (int(s) >> 5) & 0x1F
 }
 
 func (s *SchlubID) SetItemID(item int) {
@@ -128,7 +128,7 @@ func (s *SchlubID) SetItemID(item int) {
 	if item < 0 || item > 31 {
 		panic("item must be between 0 and 31")
 	}
-	*s = SchlubID((int(*s) & 0xFFFFFE1F) | (item << 4))
+	*s = SchlubID((int(*s) &^ 0x3E0) | (item << 5))
 }
 
 func (s SchlubID) AgeID() int {
@@ -141,5 +141,5 @@ func (s *SchlubID) SetAgeID(age int) {
 	if age < 0 || age > 31 {
 		panic("age must be between 0 and 31")
 	}
-	*s = SchlubID((int(*s) & 0xFFFFFFE0) | (age & 0x1F))
+	*s = SchlubID((int(*s) &^ 0x1F) | (age & 0x1F))
 }
@@ -0,0 +1,108 @@
+package world
+
+import "testing"
+
+// fields are the parts of a schlub ID, in the order they're laid out from the top bit down.
+type fields struct {
+	family, schlub, kind, item, age int
+}
+
+func fieldsOf(s SchlubID) fields {
+	return fields{s.FamilyID(), s.SchlubID(), s.KindID(), s.ItemID(), s.AgeID()}
+}
+
+// build makes a schlub ID out of the fields by walking NextFamily and NextSchlub up to them, then setting the rest.
+func build(f fields) SchlubID {
+	var s SchlubID
+	for range f.family {
+		s = s.NextFamily()
+	}
+	for range f.schlub {
+		s = s.NextSchlub()
+	}
+	s.SetKindID(f.kind)
+	s.SetItemID(f.item)
+	s.SetAgeID(f.age)
+	return s
+}
+
+func TestSchlubIDFields(t *testing.T) {
+	for _, f := range []fields{
+		{},
+		{family: 1},
+		{schlub: 1},
+		{kind: 1},
+		{item: 1},
+		{age: 1},
+		{family: 511, schlub: 1023, kind: 6, item: 31, age: 31},
+		{family: 511},
+		{schlub: 1023},
+		{kind: 6},
+		{item: 31},
+		{age: 31},
+		{family: 257, schlub: 513, kind: 5, item: 17, age: 9},
+	} {
+		s := build(f)
+		if got := fieldsOf(s); got != f {
+			t.Errorf("%+v came back as %+v (%s)", f, got, s.BitsAsString())
+		}
+		// Setting each field again leaves the others alone.
+		s.SetAgeID(f.age)
+		s.SetItemID(f.item)
+		s.SetKindID(f.kind)
+		if got := fieldsOf(s); got != f {
+			t.Errorf("%+v came back as %+v after setting it again", f, got)
+		}
+	}
+}
+
+func TestSchlubIDBits(t *testing.T) {
+	// Each field on its own lands exactly on its bits, so one off by one can't spill into the next.
+	for _, tt := range []struct {
+		f    fields
+		bits int
+	}{
+		{fields{age: 31}, 0x1F},
+		{fields{item: 31}, 0x1F << 5},
+		{fields{kind: 6}, 6 << 10},
+		{fields{schlub: 1023}, 0x3FF << 13},
+		{fields{family: 511}, 0x1FF << 23},
+	} {
+		if got := int(build(tt.f)); got != tt.bits {
+			t.Errorf("%+v is %032b, want %032b", tt.f, got, tt.bits)
+		}
+	}
+}
+
+func TestNextFamily(t *testing.T) {
+	s := build(fields{family: 3, schlub: 10, kind: int(SchlubKindWarrior), item: 4, age: 20})
+	if got := fieldsOf(s.NextFamily()); got != (fields{family: 4}) {
+		t.Errorf("next family is %+v", got)
+	}
+	if got := build(fields{family: 511}).NextFamily(); got != 0 {
+		t.Errorf("family after the last is %+v", fieldsOf(got))
+	}
+}
+
+func TestNextSchlub(t *testing.T) {
+	s := build(fields{family: 3, schlub: 10, kind: int(SchlubKindWarrior), item: 4, age: 20})
+	// New schlubs are born into the same family and kind, empty handed and brand new.
+	if got, want := fieldsOf(s.NextSchlub()), (fields{family: 3, schlub: 11, kind: int(SchlubKindWarrior)}); got != want {
+		t.Errorf("next schlub is %+v, want %+v", got, want)
+	}
+	last := build(fields{family: 511, schlub: 1023, kind: 6, item: 31, age: 31})
+	if got, want := fieldsOf(last.NextSchlub()), (fields{family: 511, kind: 6}); got != want {
+		t.Errorf("schlub after the last is %+v, want %+v", got, want)
+	}
+
+	seen := make(map[SchlubID]bool)
+	for _, schlub := range s.NextSchlubs(1000) {
+		if seen[schlub] {
+			t.Fatalf("%+v came up twice", fieldsOf(schlub))
+		}
+		seen[schlub] = true
+		if schlub.FamilyID() != 3 {
+			t.Fatalf("%+v left the family", fieldsOf(schlub))
+		}
+	}
+}
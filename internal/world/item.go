@@ -0,0 +1,112 @@
+package world
+
+import "image/color"
+
+// Item is something a schlub can carry, stored in the item bits of its SchlubID.
+type Item int
+
+const (
+	ItemNone Item = iota
+	ItemSpear
+	ItemShield
+	ItemBanner
+	ItemRations
+	ItemBoots
+	ItemCharm
+	ItemCount // Total number of items, including none.
+)
+
+// ItemInfo is what the catalog knows about an item.
+type ItemInfo struct {
+	Name  string
+	Stats Stats       // Added to the carrying schlub's contribution to its mob's stats.
+	Color color.NRGBA // What the item looks like, until it gets some art.
+}
+
+// items is the item catalog.
+var items = [ItemCount]ItemInfo{
+	ItemNone:    {Name: "Nothing"},
+	ItemSpear:   {Name: "Spear", Stats: Stats{Strength: 2}, Color: color.NRGBA{200, 200, 210, 255}},
+	ItemShield:  {Name: "Shield", Stats: Stats{Endurance: 2, Agility: -1}, Color: color.NRGBA{140, 90, 40, 255}},
+	ItemBanner:  {Name: "Banner", Stats: Stats{Charisma: 2}, Color: color.NRGBA{220, 40, 40, 255}},
+	ItemRations: {Name: "Rations", Stats: Stats{Endurance: 1}, Color: color.NRGBA{230, 200, 120, 255}},
+	ItemBoots:   {Name: "Boots", Stats: Stats{Agility: 2}, Color: color.NRGBA{90, 60, 30, 255}},
+	ItemCharm:   {Name: "Charm", Stats: Stats{Luck: 2}, Color: color.NRGBA{80, 220, 120, 255}},
+}
+
+// Info returns the catalog entry for the item. Unknown items are nothing.
+func (i Item) Info() ItemInfo {
+	if i < 0 || i >= ItemCount {
+		return items[ItemNone]
+	}
+	return items[i]
+}
+
+func (i Item) String() string {
+	return i.Info().Name
+}
+
+// Item returns the item the schlub is carrying.
+func (s SchlubID) Item() Item {
+	return Item(s.ItemID())
+}
+
+// ItemDrop is an item lying on the ground, waiting for a schlub to pick it up.
+type ItemDrop struct {
+	ID   ID
+	Item Item
+	X, Y float64
+}
+
+// ItemDropRadius is how close a mob has to get to an item drop to pick it up.
+const ItemDropRadius = 6.0
+
+// ItemDrops is a slice of item drops.
+type ItemDrops []*ItemDrop
+
+// FindByID searches for an item drop by its ID.
+func (d *ItemDrops) FindByID(id ID) *ItemDrop {
+	for _, drop := range *d {
+		if drop.ID == id {
+			return drop
+		}
+	}
+	return nil
+}
+
+// Add appends an item drop.
+func (d *ItemDrops) Add(drop *ItemDrop) {
+	*d = append(*d, drop)
+}
+
+// Remove deletes an item drop.
+func (d *ItemDrops) Remove(drop *ItemDrop) {
+	for i, existing := range *d {
+		if existing == drop {
+			*d = append((*d)[:i], (*d)[i+1:]...)
+			return
+		}
+	}
+}
+
+// FindTouching returns the item drops the mob is standing on.
+func (d *ItemDrops) FindTouching(mob *Mob) ItemDrops {
+	var touching ItemDrops
+	for _, drop := range *d {
+		if CircleIntersectsCircle(mob.X, mob.Y, mob.Radius(), drop.X, drop.Y, ItemDropRadius) {
+			touching = append(touching, drop)
+		}
+	}
+	return touching
+}
+
+// FindVisible returns the item drops the mob can see.
+func (d *ItemDrops) FindVisible(mob *Mob) ItemDrops {
+	var visible ItemDrops
+	for _, drop := range *d {
+		if CircleIntersectsCircle(mob.X, mob.Y, mob.Vision(), drop.X, drop.Y, ItemDropRadius) {
+			visible = append(visible, drop)
+		}
+	}
+	return visible
+}
@@ -0,0 +1,68 @@
+package world
+
+import "testing"
+
+func TestItemInfo(t *testing.T) {
+	for item := ItemNone; item < ItemCount; item++ {
+		if item.Info().Name == "" {
+			t.Errorf("item %d has no name", item)
+		}
+	}
+	for _, item := range []Item{-1, ItemCount, 31} {
+		if item.Info() != items[ItemNone] {
+			t.Errorf("unknown item %d is %s", item, item)
+		}
+	}
+	if got := schlub(SchlubKindWarrior, adult, ItemCharm).Item(); got != ItemCharm {
+		t.Errorf("schlub is carrying %s, want a charm", got)
+	}
+}
+
+func TestItemStats(t *testing.T) {
+	for item := ItemNone + 1; item < ItemCount; item++ {
+		empty := *StatsFor([]SchlubID{schlub(SchlubKindVagrant, adult, ItemNone)})
+		info := item.Info().Stats
+		want := empty.Apply(&info)
+		if got := StatsFor([]SchlubID{schlub(SchlubKindVagrant, adult, item)}); *got != *want {
+			t.Errorf("vagrant with %s = %+v, want %+v", item, *got, *want)
+		}
+	}
+	// One spear in a crowd only goes so far.
+	crowd := []SchlubID{
+		schlub(SchlubKindVagrant, adult, ItemSpear),
+		schlub(SchlubKindVagrant, adult, ItemNone),
+		schlub(SchlubKindVagrant, adult, ItemNone),
+	}
+	if got, want := StatsFor(crowd).Strength, StatsFor(crowd[1:]).Strength+1; got != want {
+		t.Errorf("one spear in three gave strength %s, want %s", got, want)
+	}
+}
+
+func TestItemDrops(t *testing.T) {
+	var drops ItemDrops
+	near := &ItemDrop{ID: 1, Item: ItemSpear, X: 100, Y: 100}
+	far := &ItemDrop{ID: 2, Item: ItemBoots, X: 100 + 10000, Y: 100}
+	drops.Add(near)
+	drops.Add(far)
+	if drops.FindByID(2) != far || drops.FindByID(3) != nil {
+		t.Errorf("FindByID found the wrong drops")
+	}
+
+	mob := &Mob{X: 100 + ItemDropRadius, Y: 100}
+	if got := drops.FindTouching(mob); len(got) != 1 || got[0] != near {
+		t.Errorf("mob is touching %d drops, want the near one", len(got))
+	}
+	if got := drops.FindVisible(mob); len(got) != 1 || got[0] != near {
+		t.Errorf("mob can see %d drops, want the near one", len(got))
+	}
+	mob.X = 100 + mob.Radius() + ItemDropRadius + 1
+	if got := drops.FindTouching(mob); len(got) != 0 {
+		t.Errorf("mob is touching %d drops from afar", len(got))
+	}
+
+	drops.Remove(near)
+	drops.Remove(near)
+	if len(drops) != 1 || drops[0] != far {
+		t.Errorf("removing left %d drops", len(drops))
+	}
+}
@@ -40,6 +40,17 @@ func (m *Mobs) FindByID(id ID) *Mob {
 	return nil
 }
 
+// FindByOwner returns all mobs owned by the given owner ID.
+func (m *Mobs) FindByOwner(owner ID) Mobs {
+	var owned Mobs
+	for _, mob := range *m {
+		if mob.OwnerID == owner {
+			owned = append(owned, mob)
+		}
+	}
+	return owned
+}
+
 // Add appends a new mob to the Mobs slice.
 func (m *Mobs) Add(mob *Mob) {
 	if slices.Contains(*m, mob) {
@@ -67,38 +78,37 @@ type Mob struct {
 	lastWanderTick   int         // Last tick we wandered, used to prevent immediate re-wandering
 	TargetX, TargetY float64     // Target position to move to
 	TargetID         ID
-	Stats            *Stats // Stats of the mob
+	Path             []Waypoint // Waypoints left to get to the target position, if it can't be walked to straight.
+	Stats            *Stats     // Stats of the mob
 	Schlubs          []SchlubID
 	OuterKind        SchlubID // Outer kind of the mob, used for formation
 	SpawnCheckTick   int      // Tick to iterate our schlubs and spawn check
 	SpawnCheckChunk  int      // Iterate using the above tick to check and spawn schlubs from caravans.
+	Food             int      // Food foraged and not yet eaten.
 }
 
 // Update does Mob logic, woo
 func (m *Mob) Update(state *State) {
-	speed := m.Speed() // * float64(state.Tickrate)
+	m.RefreshStats(state.Continent.GetContainingFief(m.X, m.Y), state.Continent.OwnedFiefs(m.OwnerID)...)
+	// Schlubs come and go, so make sure we're still filed in the right place for our size.
+	state.Continent.mobIndex().refile(m)
+	speed := m.MoveSpeed(state.Continent.TerrainAt(m.X, m.Y)) // * float64(state.Tickrate)
 
 	// If we're a "barbarian" mob (OwnerID == 0), we don't have a target.
 	if m.OwnerID == 0 {
 		if m.TargetID == 0 {
-			fief := state.Continent.GetContainingFief(m.X, m.Y)
-			if fief != nil {
-				visibleMobs := fief.Mobs.FindVisible(m.ID)
-				if len(visibleMobs) > 0 {
-					// Pick a random visible mob as the target.
-					targetMob := visibleMobs[state.Continent.Fate.NumGen.Intn(len(visibleMobs))]
-					// If they have fewer schlubs than us, we target them.
-					if len(targetMob.Schlubs) < len(m.Schlubs) {
-						m.TargetID = targetMob.ID
-					} else {
-						m.TargetID = 0
-					}
+			if visibleMobs := state.Continent.VisibleMobs(m); len(visibleMobs) > 0 {
+				// Pick a random visible mob as the target.
+				targetMob := visibleMobs[state.Continent.Fate.NumGen.Intn(len(visibleMobs))]
+				// If they have fewer schlubs than us, we target them.
+				if len(targetMob.Schlubs) < len(m.Schlubs) {
+					m.TargetID = targetMob.ID
 				} else {
-					// No visible mobs, reset target.
 					m.TargetID = 0
 				}
 			} else {
-				m.TargetID = 0 // Reset if no fief found
+				// No visible mobs, reset target.
+				m.TargetID = 0
 			}
 		}
 		// Eh, let's wander randomly if we don't have a target.
@@ -106,37 +116,27 @@ func (m *Mob) Update(state *State) {
 			m.lastWanderTick++
 			if m.lastWanderTick > 40 {
 				m.lastWanderTick = 0
-				m.TargetX = m.X + (state.Continent.Fate.NumGen.Float64()*10)*speed
-				m.TargetY = m.Y + (state.Continent.Fate.NumGen.Float64()*10)*speed
+				m.MoveTo(state.Continent,
+					m.X+(state.Continent.Fate.NumGen.Float64()*10)*speed,
+					m.Y+(state.Continent.Fate.NumGen.Float64()*10)*speed,
+				)
 			}
 		}
 	}
 
-	// Acquire our target mob if we have one set.
+	// Acquire our target mob if we have one set. Chasing goes straight at them, they won't hold still long enough for a path.
 	if m.TargetID != 0 {
-		if mob := state.Continent.Mobs.FindByID(m.TargetID); mob != nil {
+		if mob := state.Continent.FindMob(m.TargetID); mob != nil {
 			m.TargetX = mob.X
 			m.TargetY = mob.Y
+			m.Path = nil
 		} else {
 			m.TargetID = 0 // Reset if target mob is not found
 		}
 	}
 
-	// Move towards our destiny.
-	if m.X != m.TargetX || m.Y != m.TargetY {
-		angleToTarget := math.Atan2(m.TargetY-m.Y, m.TargetX-m.X)
-		dx := math.Cos(angleToTarget)
-		dy := math.Sin(angleToTarget)
-		x := m.X + dx*speed
-		y := m.Y + dy*speed
-
-		if math.Abs(x-m.TargetX) < speed {
-			x = m.TargetX
-		}
-		if math.Abs(y-m.TargetY) < speed {
-			y = m.TargetY
-		}
-
+	// Move towards our destiny, by way of the next waypoint if we have any.
+	if x, y, moving := m.Step(speed); moving {
 		state.EventBus.Publish(&event.MobPosition{ID: m.ID, X: x, Y: y})
 	}
 
@@ -179,10 +179,35 @@ func (m *Mob) Update(state *State) {
 
 }
 
+// MoveTo sends the mob to the given position, finding a path around anything in the way.
+func (m *Mob) MoveTo(c *Continent, x, y float64) {
+	m.TargetID = 0
+	m.TargetX = x
+	m.TargetY = y
+	m.Path = c.Path(m, x, y)
+}
+
+// Stop has the mob stay where it is.
+func (m *Mob) Stop() {
+	m.TargetX = m.X
+	m.TargetY = m.Y
+	m.Path = nil
+}
+
 func (m *Mob) AddSchlub(schlub ...SchlubID) {
 	m.Schlubs = append(m.Schlubs, schlub...)
 }
 
+// HasSchlubs returns true if every given schlub is in the mob, with no duplicates requested.
+func (m *Mob) HasSchlubs(schlubs ...SchlubID) bool {
+	for i, id := range schlubs {
+		if !slices.Contains(m.Schlubs, id) || slices.Contains(schlubs[:i], id) {
+			return false
+		}
+	}
+	return true
+}
+
 func (m *Mob) RemoveSchlub(schlub ...SchlubID) {
 	for _, id := range schlub {
 		for i, existingSchlub := range m.Schlubs {
@@ -209,6 +234,42 @@ func (m *Mob) CombatRadius() float64 {
 	return math.Max(12, math.Log(float64(len(m.Schlubs)))*20)
 }
 
+// RefreshStats recalculates the mob's stats from its schlubs, the modifiers of the fief it's in, and the modifiers of any fiefs its owner has claimed. Owning a dozen marshes is no worse than owning one, so each kind of modifier only counts once.
+func (m *Mob) RefreshStats(fief *Fief, owned ...*Fief) {
+	var modifiers []Modifier
+	if fief != nil {
+		modifiers = append(modifiers, fief.Modifiers()...)
+	}
+	for _, claimed := range owned {
+		for _, modifier := range claimed.Modifiers() {
+			if !slices.ContainsFunc(modifiers, func(other Modifier) bool {
+				return other.Reason == modifier.Reason
+			}) {
+				modifiers = append(modifiers, modifier)
+			}
+		}
+	}
+	m.Stats = StatsFor(m.Schlubs, modifiers...)
+}
+
+// stats returns the mob's stats, working them out from its schlubs alone if they haven't been refreshed yet.
+func (m *Mob) stats() *Stats {
+	if m.Stats == nil {
+		return StatsFor(m.Schlubs)
+	}
+	return m.Stats
+}
+
+// Damage returns how many of the other mob's schlubs this mob slays in a clash, pitting our strength against their endurance.
+func (m *Mob) Damage(other *Mob, base int) int {
+	return ClashCount(base, m.stats().Strength, other.stats().Endurance)
+}
+
+// Conversions returns how many of the other mob's schlubs this mob converts in a clash, pitting our charisma against theirs.
+func (m *Mob) Conversions(other *Mob, base int) int {
+	return ClashCount(base, m.stats().Charisma, other.stats().Charisma)
+}
+
 func (m *Mob) Speed() float64 {
 	// Faster the smaller you be.
 	if len(m.Schlubs) == 0 {
@@ -216,6 +277,8 @@ func (m *Mob) Speed() float64 {
 	}
 	// Every 50 schlubs, we reduce speed by 0.01
 	speed := 1.0 - (float64(len(m.Schlubs))/50)*0.01
+	// Every rank of agility above or below iron is 10% faster or slower.
+	speed *= 1 + 0.1*float64(m.stats().Agility-RankIron)
 	if speed < 0.1 {
 		speed = 0.1 // Minimum speed
 	}
@@ -232,6 +295,8 @@ func (m *Mob) Spread() float64 {
 // Vision returns the mob's vision radius.
 func (m *Mob) Vision() float64 {
 	vision := math.Max(200, math.Log(m.Radius())*50)
+	// Lucky mobs spot more, 10% per rank of luck above or below iron.
+	vision *= 1 + 0.1*float64(m.stats().Luck-RankIron)
 	return vision
 }
 
@@ -0,0 +1,153 @@
+package world
+
+import "slices"
+
+const (
+	MobCellSize = float64(FiefPixelSpan)                  // Size of the cells mobs are filed under for finding what's nearby.
+	mobCells    = int(ContinentPixelSpan/MobCellSize) + 1 // Cells per row, with one spare for mobs sat right on the far edge.
+	bigMob      = -1                                      // Cell for mobs too big to file under just one.
+)
+
+// mobIndex keeps track of where mobs are so finding them doesn't mean going over every mob. Mobs are filed under the cell their middle is in, unless they're bigger than a cell, in which case they're always checked.
+type mobIndex struct {
+	byID    map[ID]*Mob
+	byOwner map[ID]Mobs
+	cells   []Mobs
+	big     Mobs
+	cell    map[ID]int // Cell each mob is filed under.
+}
+
+// mobIndex returns the continent's mob index, making it if need be.
+func (c *Continent) mobIndex() *mobIndex {
+	if c.index == nil {
+		c.index = &mobIndex{
+			byID:    make(map[ID]*Mob),
+			byOwner: make(map[ID]Mobs),
+			cells:   make([]Mobs, mobCells*mobCells),
+			cell:    make(map[ID]int),
+		}
+	}
+	return c.index
+}
+
+// cellFor returns the cell the mob should be filed under.
+func cellFor(mob *Mob) int {
+	if mob.Radius() > MobCellSize {
+		return bigMob
+	}
+	return cellAt(mob.X, mob.Y)
+}
+
+// cellAt returns the cell containing the given pixel coordinates.
+func cellAt(x, y float64) int {
+	cx := min(max(int(x/MobCellSize), 0), mobCells-1)
+	cy := min(max(int(y/MobCellSize), 0), mobCells-1)
+	return cy*mobCells + cx
+}
+
+func (i *mobIndex) add(mob *Mob) {
+	if _, ok := i.byID[mob.ID]; ok {
+		return
+	}
+	i.byID[mob.ID] = mob
+	i.byOwner[mob.OwnerID] = append(i.byOwner[mob.OwnerID], mob)
+	i.file(mob, cellFor(mob))
+}
+
+func (i *mobIndex) remove(mob *Mob) {
+	if i.byID[mob.ID] != mob {
+		return
+	}
+	delete(i.byID, mob.ID)
+	owned := i.byOwner[mob.OwnerID]
+	owned.Remove(mob)
+	if len(owned) == 0 {
+		delete(i.byOwner, mob.OwnerID)
+	} else {
+		i.byOwner[mob.OwnerID] = owned
+	}
+	i.unfile(mob)
+}
+
+// refile moves the mob to the right cell if it's moved or grown out of the one it's in.
+func (i *mobIndex) refile(mob *Mob) {
+	cell, ok := i.cell[mob.ID]
+	if !ok {
+		return
+	}
+	if next := cellFor(mob); next != cell {
+		i.unfile(mob)
+		i.file(mob, next)
+	}
+}
+
+func (i *mobIndex) file(mob *Mob, cell int) {
+	i.cell[mob.ID] = cell
+	if cell == bigMob {
+		i.big = append(i.big, mob)
+	} else {
+		i.cells[cell] = append(i.cells[cell], mob)
+	}
+}
+
+func (i *mobIndex) unfile(mob *Mob) {
+	cell := i.cell[mob.ID]
+	delete(i.cell, mob.ID)
+	if cell == bigMob {
+		i.big.Remove(mob)
+	} else {
+		i.cells[cell].Remove(mob)
+	}
+}
+
+// near returns the mobs touching the given circle.
+func (i *mobIndex) near(x, y, radius float64) Mobs {
+	var found Mobs
+	// Mobs filed under a cell can hang over its edge by up to a cell.
+	reach := radius + MobCellSize
+	minX, minY := cellAt(x-reach, y-reach)%mobCells, cellAt(x-reach, y-reach)/mobCells
+	maxX, maxY := cellAt(x+reach, y+reach)%mobCells, cellAt(x+reach, y+reach)/mobCells
+	for cy := minY; cy <= maxY; cy++ {
+		for cx := minX; cx <= maxX; cx++ {
+			for _, mob := range i.cells[cy*mobCells+cx] {
+				if CircleIntersectsCircle(x, y, radius, mob.X, mob.Y, mob.Radius()) {
+					found = append(found, mob)
+				}
+			}
+		}
+	}
+	for _, mob := range i.big {
+		if CircleIntersectsCircle(x, y, radius, mob.X, mob.Y, mob.Radius()) {
+			found = append(found, mob)
+		}
+	}
+	return found
+}
+
+// FindMob returns the mob with the given ID, or nil if there isn't one.
+func (c *Continent) FindMob(id ID) *Mob {
+	return c.mobIndex().byID[id]
+}
+
+// OwnedMobs returns the mobs owned by the given owner. The slice is the caller's to keep, so it's safe to remove mobs while going over it.
+func (c *Continent) OwnedMobs(owner ID) Mobs {
+	return slices.Clone(c.mobIndex().byOwner[owner])
+}
+
+// MobsNear returns the mobs touching the given circle.
+func (c *Continent) MobsNear(x, y, radius float64) Mobs {
+	return c.mobIndex().near(x, y, radius)
+}
+
+// VisibleMobs returns the mobs the mob can see, itself included.
+func (c *Continent) VisibleMobs(mob *Mob) Mobs {
+	return c.MobsNear(mob.X, mob.Y, mob.Vision())
+}
+
+// IntersectingMobs returns the other mobs the mob is bumping into.
+func (c *Continent) IntersectingMobs(mob *Mob) Mobs {
+	found := c.MobsNear(mob.X, mob.Y, mob.Radius())
+	return slices.DeleteFunc(found, func(other *Mob) bool {
+		return other == mob
+	})
+}
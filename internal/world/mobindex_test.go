@@ -0,0 +1,113 @@
+package world
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// scatter adds count mobs with a few schlubs each at random over a square of the given span in the continent's top left.
+func scatter(c *Continent, count int, span float64, rng *rand.Rand) {
+	for i := range count {
+		mob := c.NewMob(ID(i%5), ID(i+1), rng.Float64()*span, rng.Float64()*span)
+		for j := range rng.Intn(60) {
+			mob.AddSchlub(SchlubID(j))
+		}
+		c.mobIndex().refile(mob)
+	}
+}
+
+// touching finds the mobs touching the circle the slow way.
+func touching(c *Continent, x, y, radius float64) int {
+	count := 0
+	for _, mob := range c.Mobs {
+		if CircleIntersectsCircle(x, y, radius, mob.X, mob.Y, mob.Radius()) {
+			count++
+		}
+	}
+	return count
+}
+
+func TestMobIndexMatchesScan(t *testing.T) {
+	c := NewContinent(1)
+	c.ClearMobs()
+	rng := rand.New(rand.NewSource(1))
+	scatter(c, 500, ContinentPixelSpan, rng)
+	for range 50 {
+		for _, mob := range c.Mobs {
+			mob.X = clamp64(mob.X+rng.Float64()*200-100, 0, continentEdge)
+			mob.Y = clamp64(mob.Y+rng.Float64()*200-100, 0, continentEdge)
+			if rng.Intn(3) == 0 {
+				// Shrink some so they get refiled out of the big mobs.
+				mob.Schlubs = mob.Schlubs[:len(mob.Schlubs)/2]
+			}
+			c.mobIndex().refile(mob)
+		}
+		for range 50 {
+			x, y, radius := rng.Float64()*ContinentPixelSpan, rng.Float64()*ContinentPixelSpan, rng.Float64()*400
+			if got, want := len(c.MobsNear(x, y, radius)), touching(c, x, y, radius); got != want {
+				t.Fatalf("MobsNear(%v, %v, %v) found %d mobs, want %d", x, y, radius, got, want)
+			}
+		}
+	}
+
+	for _, mob := range append(Mobs{}, c.Mobs...) {
+		if c.FindMob(mob.ID) != mob {
+			t.Fatalf("FindMob(%d) didn't find it", mob.ID)
+		}
+		if !slices.Contains(c.OwnedMobs(mob.OwnerID), mob) {
+			t.Fatalf("OwnedMobs(%d) is missing mob %d", mob.OwnerID, mob.ID)
+		}
+		c.RemoveMob(mob)
+	}
+	if len(c.index.byID) != 0 || len(c.index.byOwner) != 0 || len(c.index.cell) != 0 || len(c.index.big) != 0 {
+		t.Errorf("index still has mobs after they were all removed")
+	}
+}
+
+func TestIntersectingMobs(t *testing.T) {
+	c := NewContinent(1)
+	c.ClearMobs()
+	a := c.NewMob(1, 1, 100, 100)
+	b := c.NewMob(2, 2, 115, 100)
+	c.NewMob(3, 3, 300, 300)
+	got := c.IntersectingMobs(a)
+	if len(got) != 1 || got[0] != b {
+		t.Errorf("IntersectingMobs found %v, want just mob 2", got)
+	}
+}
+
+// benchmarkMobs runs the query over every mob in turn, with the mobs spread over the whole continent and crammed into one corner of it, at a few different counts.
+func benchmarkMobs(b *testing.B, query func(c *Continent, mob *Mob)) {
+	for _, layout := range []struct {
+		name string
+		span float64
+	}{
+		{"spread", ContinentPixelSpan},
+		{"crowded", ContinentPixelSpan / 8},
+	} {
+		for _, count := range []int{200, 1000, 5000} {
+			c := NewContinent(1)
+			c.ClearMobs()
+			scatter(c, count, layout.span, rand.New(rand.NewSource(1)))
+			b.Run(fmt.Sprintf("%s-%d", layout.name, count), func(b *testing.B) {
+				for i := range b.N {
+					query(c, c.Mobs[i%count])
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkVisibleMobs(b *testing.B) {
+	benchmarkMobs(b, func(c *Continent, mob *Mob) {
+		c.VisibleMobs(mob)
+	})
+}
+
+func BenchmarkIntersectingMobs(b *testing.B) {
+	benchmarkMobs(b, func(c *Continent, mob *Mob) {
+		c.IntersectingMobs(mob)
+	})
+}
@@ -0,0 +1,123 @@
+package world
+
+import "math"
+
+const (
+	CaravanWaterMultiplier = 0.5  // How fast caravans ford water.
+	StrandedMultiplier     = 0.25 // How fast mobs that somehow ended up in water wade back out.
+	moveTolerance          = 1.5  // Leeway on how far a mob can move in one go, for snapping onto its target.
+)
+
+// MoveMultiplier returns how fast mobs move over the terrain compared to open ground. Water is impassable, only caravans can cross it.
+func (t Terrain) MoveMultiplier() float64 {
+	switch t {
+	case TerrainWater:
+		return 0
+	case TerrainRocks:
+		return 0.6
+	case TerrainPines:
+		return 0.7
+	case TerrainRockySand:
+		return 0.75
+	case TerrainSand, TerrainRockyDirt, TerrainGrassyRocks:
+		return 0.8
+	case TerrainSandyDirt, TerrainGrassySand:
+		return 0.9
+	}
+	return 1
+}
+
+// IsCaravan returns true if the mob has any caravans with it, which can carry it across water.
+func (m *Mob) IsCaravan() bool {
+	for _, schlub := range m.Schlubs {
+		switch SchlubID(schlub.KindID()) {
+		case SchlubKindCaravanVagrant, SchlubKindCaravanMonk, SchlubKindCaravanWarrior:
+			return true
+		}
+	}
+	return false
+}
+
+// TerrainMultiplier returns how fast the mob moves over the terrain compared to open ground, 0 if it can't go there at all.
+func (m *Mob) TerrainMultiplier(terrain Terrain) float64 {
+	if terrain == TerrainWater && m.IsCaravan() {
+		return CaravanWaterMultiplier
+	}
+	return terrain.MoveMultiplier()
+}
+
+// MoveSpeed returns how far the mob moves in a tick over the terrain. Mobs stranded on terrain they can't cross can still crawl off it.
+func (m *Mob) MoveSpeed(terrain Terrain) float64 {
+	multiplier := m.TerrainMultiplier(terrain)
+	if multiplier == 0 {
+		multiplier = StrandedMultiplier
+	}
+	return m.Speed() * multiplier
+}
+
+// TileAt returns the tile at the given pixel coordinates, or nil if they're off the continent.
+func (c *Continent) TileAt(x, y float64) *Tile {
+	idx := c.FiefIndex(x, y)
+	if idx < 0 {
+		return nil
+	}
+	return c.Fiefs[idx].GetTileAt(x, y)
+}
+
+// TerrainAt returns the terrain at the given pixel coordinates, TerrainNone if they're off the continent.
+func (c *Continent) TerrainAt(x, y float64) Terrain {
+	if tile := c.TileAt(x, y); tile != nil {
+		return tile.Terrain
+	}
+	return TerrainNone
+}
+
+// Passable returns true if the mob can walk onto the given pixel coordinates.
+func (c *Continent) Passable(mob *Mob, x, y float64) bool {
+	return mob.TerrainMultiplier(c.TerrainAt(x, y)) > 0
+}
+
+// CanStep returns true if the mob can step from where it is onto the given pixel coordinates. Mobs stranded on terrain they can't cross can go anywhere, so long as it's out.
+func (c *Continent) CanStep(mob *Mob, x, y float64) bool {
+	return c.Passable(mob, x, y) || mob.TerrainMultiplier(c.TerrainAt(mob.X, mob.Y)) == 0
+}
+
+// Step returns where the mob gets to moving distance towards its next waypoint, or its target if it has none, dropping waypoints it's already reached. It returns false if the mob is already at its target.
+func (m *Mob) Step(distance float64) (float64, float64, bool) {
+	if m.X == m.TargetX && m.Y == m.TargetY {
+		m.Path = nil // Made it.
+		return m.X, m.Y, false
+	}
+	if len(m.Path) > 0 && m.X == m.Path[0].X && m.Y == m.Path[0].Y {
+		m.Path = m.Path[1:]
+	}
+	goalX, goalY := m.TargetX, m.TargetY
+	if len(m.Path) > 0 {
+		goalX, goalY = m.Path[0].X, m.Path[0].Y
+	}
+	angleToGoal := math.Atan2(goalY-m.Y, goalX-m.X)
+	x := m.X + math.Cos(angleToGoal)*distance
+	y := m.Y + math.Sin(angleToGoal)*distance
+
+	if math.Abs(x-goalX) < distance {
+		x = goalX
+	}
+	if math.Abs(y-goalY) < distance {
+		y = goalY
+	}
+	return x, y, true
+}
+
+// limitMove returns how far towards x, y the mob can get in one move. Mobs can't walk onto terrain they can't cross, unless they're already stuck on it and wading out, and can't outrun the terrain they're on.
+func (c *Continent) limitMove(mob *Mob, x, y float64) (float64, float64, bool) {
+	if !c.CanStep(mob, x, y) {
+		return mob.X, mob.Y, false
+	}
+	step := math.Hypot(x-mob.X, y-mob.Y)
+	maxStep := mob.MoveSpeed(c.TerrainAt(mob.X, mob.Y)) * moveTolerance
+	if step > maxStep {
+		x = mob.X + (x-mob.X)*maxStep/step
+		y = mob.Y + (y-mob.Y)*maxStep/step
+	}
+	return x, y, true
+}
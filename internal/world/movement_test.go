@@ -0,0 +1,48 @@
+package world
+
+import "testing"
+
+func TestStepFollowsPath(t *testing.T) {
+	mob := &Mob{X: 0, Y: 0, TargetX: 10, TargetY: 10, Path: []Waypoint{{10, 0}, {10, 10}}}
+	var x, y float64
+	moves := 0
+	for moving := true; moving; moves++ {
+		if x, y, moving = mob.Step(3); moving {
+			mob.X, mob.Y = x, y
+		}
+		if mob.Y > 0 && mob.X < 10 {
+			t.Fatalf("cut the corner at %v, %v", mob.X, mob.Y)
+		}
+		if moves > 20 {
+			t.Fatalf("never got there, stuck at %v, %v", mob.X, mob.Y)
+		}
+	}
+	if mob.X != 10 || mob.Y != 10 || mob.Path != nil {
+		t.Errorf("ended at %v, %v with path %v", mob.X, mob.Y, mob.Path)
+	}
+}
+
+func TestCanStep(t *testing.T) {
+	c := NewContinent(1)
+	c.ClearMobs()
+	paint(c, 0, 0, 4, 4, TerrainGrass)
+	paint(c, 2, 0, 4, 4, TerrainWater)
+	mob := c.NewMob(1, 1, pixel(0), pixel(0))
+	if !c.CanStep(mob, pixel(1), pixel(0)) {
+		t.Error("can't step onto grass")
+	}
+	if c.CanStep(mob, pixel(2), pixel(0)) {
+		t.Error("stepped into the water")
+	}
+	var caravan SchlubID
+	caravan.SetKindID(int(SchlubKindCaravanVagrant))
+	mob.AddSchlub(caravan)
+	if !c.CanStep(mob, pixel(2), pixel(0)) {
+		t.Error("caravan can't ford")
+	}
+
+	stranded := c.NewMob(1, 2, pixel(3), pixel(3))
+	if !c.CanStep(stranded, pixel(1), pixel(3)) {
+		t.Error("stranded mob can't wade out")
+	}
+}
@@ -0,0 +1,46 @@
+package world
+
+import "strings"
+
+// Syllables fief names are stitched together from.
+var (
+	namePrefixes = []string{
+		"Ash", "Bel", "Bram", "Brig", "Cal", "Cor", "Dun", "El", "Fen", "Glen", "Gos", "Har", "Hol", "Kel", "Kin",
+		"Lin", "Lud", "Mar", "Mor", "Nor", "Oak", "Pen", "Quil", "Ros", "Scar", "Stan", "Thorn", "Ul", "Wen", "Wil", "Yar",
+	}
+	nameMiddles  = []string{"a", "en", "er", "i", "in", "o", "ock", "ing"}
+	nameSuffixes = []string{
+		"bury", "by", "combe", "dale", "den", "field", "ford", "gate", "ham", "hold", "holm", "hurst", "ley",
+		"mere", "moor", "ness", "stead", "stow", "ton", "wick", "worth", "wold",
+	}
+)
+
+// nameAttempts is how many times a fief name is rerolled before a duplicate is settled for.
+const nameAttempts = 8
+
+// fiefName returns a name for the fief at the given grid coordinates on a continent made from the given sneed, with the salt bumped to reroll duplicates. Names come out the same for the same sneed, so clients and servers agree on them without talking it over.
+func fiefName(sneed uint, x, y, salt int) string {
+	h := nameHash(uint64(sneed), uint64(x), uint64(y), uint64(salt))
+	var name strings.Builder
+	name.WriteString(namePrefixes[h%uint64(len(namePrefixes))])
+	h /= uint64(len(namePrefixes))
+	// Only some names get a middle bit, else they all end up long.
+	if h%3 == 0 {
+		name.WriteString(nameMiddles[(h/3)%uint64(len(nameMiddles))])
+	}
+	h /= 3 * uint64(len(nameMiddles))
+	name.WriteString(nameSuffixes[h%uint64(len(nameSuffixes))])
+	return name.String()
+}
+
+// nameHash mixes the values together with splitmix64, which is plenty random for names.
+func nameHash(values ...uint64) uint64 {
+	var h uint64
+	for _, v := range values {
+		h += v + 0x9e3779b97f4a7c15
+		h = (h ^ (h >> 30)) * 0xbf58476d1ce4e5b9
+		h = (h ^ (h >> 27)) * 0x94d049bb133111eb
+		h ^= h >> 31
+	}
+	return h
+}
@@ -0,0 +1,246 @@
+package world
+
+import "math"
+
+const (
+	PathMaxNodes  = 40000                                 // Tiles the pathfinder looks at before giving up and letting the mob walk straight.
+	continentGrid = ContinientFiefSpan * FiefSize         // Tiles per row across the whole continent.
+	pathDiagonal  = math.Sqrt2                            // Cost of stepping diagonally compared to straight.
+	pathTieBreak  = 1 + 1.0/(continentGrid*continentGrid) // Nudges the heuristic so ties go to whoever's closer to the goal.
+)
+
+// Waypoint is a point along a mob's path.
+type Waypoint struct {
+	X, Y float64
+}
+
+// pathNode is an entry in the pathfinder's open list.
+type pathNode struct {
+	idx int32
+	f   float32
+}
+
+// Pathfinder finds paths over the continent's tiles with A*. Its buffers are reused between searches, so it isn't safe to use from more than one goroutine at a time.
+type Pathfinder struct {
+	terrain []Terrain // Terrain of every tile on the continent, indexed by tile y*continentGrid+x.
+	cost    []float32
+	parent  []int32
+	seen    []uint32 // Search each entry in cost and parent was last touched by.
+	closed  []uint32 // Search each tile was last finished by.
+	search  uint32
+	open    []pathNode
+}
+
+// newPathfinder makes a pathfinder for the continent, copying out its terrain so lookups are cheap.
+func newPathfinder(c *Continent) *Pathfinder {
+	size := continentGrid * continentGrid
+	p := &Pathfinder{
+		terrain: make([]Terrain, size),
+		cost:    make([]float32, size),
+		parent:  make([]int32, size),
+		seen:    make([]uint32, size),
+		closed:  make([]uint32, size),
+	}
+	for i, fief := range c.Fiefs {
+		fx := (i % ContinientFiefSpan) * FiefSize
+		fy := (i / ContinientFiefSpan) * FiefSize
+		for j, tile := range fief.Tiles {
+			p.terrain[(fy+j/FiefSize)*continentGrid+fx+j%FiefSize] = tile.Terrain
+		}
+	}
+	return p
+}
+
+// Path returns waypoints for the mob to follow to get to x, y around terrain it can't cross, preferring terrain it's quicker over. The last waypoint is x, y itself. It returns nil if the mob can walk straight there, or if there's no way there it can find, in which case the mob walks straight until it's stopped.
+func (c *Continent) Path(mob *Mob, x, y float64) []Waypoint {
+	if c.pathfinder == nil {
+		c.pathfinder = newPathfinder(c)
+	}
+	return c.pathfinder.Find(mob, mob.X, mob.Y, x, y)
+}
+
+// Find looks for a path for the mob from one point to another.
+func (p *Pathfinder) Find(mob *Mob, fromX, fromY, toX, toY float64) []Waypoint {
+	start, ok1 := tileIndex(fromX, fromY)
+	goal, ok2 := tileIndex(toX, toY)
+	if !ok1 || !ok2 || start == goal {
+		return nil
+	}
+	caravan := mob.IsCaravan()
+	multiplier := func(idx int32) float32 {
+		terrain := p.terrain[idx]
+		if terrain == TerrainWater && caravan {
+			return CaravanWaterMultiplier
+		}
+		return float32(terrain.MoveMultiplier())
+	}
+	if multiplier(goal) == 0 {
+		return nil
+	}
+	if p.clear(start, goal, multiplier) {
+		return nil
+	}
+
+	p.search++
+	p.open = p.open[:0]
+	gx, gy := goal%continentGrid, goal/continentGrid
+	heuristic := func(idx int32) float32 {
+		dx := math.Abs(float64(idx%continentGrid - gx))
+		dy := math.Abs(float64(idx/continentGrid - gy))
+		// Octile distance over the quickest terrain there is.
+		return float32((dx + dy + (pathDiagonal-2)*math.Min(dx, dy)) * pathTieBreak)
+	}
+	p.touch(start, 0, -1)
+	p.push(pathNode{start, heuristic(start)})
+
+	found := false
+	for expanded := 0; len(p.open) > 0 && expanded < PathMaxNodes; expanded++ {
+		node := p.pop()
+		if p.closed[node.idx] == p.search {
+			continue
+		}
+		p.closed[node.idx] = p.search
+		if node.idx == goal {
+			found = true
+			break
+		}
+		nx, ny := node.idx%continentGrid, node.idx/continentGrid
+		for dy := int32(-1); dy <= 1; dy++ {
+			for dx := int32(-1); dx <= 1; dx++ {
+				if dx == 0 && dy == 0 {
+					continue
+				}
+				tx, ty := nx+dx, ny+dy
+				if tx < 0 || ty < 0 || tx >= continentGrid || ty >= continentGrid {
+					continue
+				}
+				next := ty*continentGrid + tx
+				if p.closed[next] == p.search {
+					continue
+				}
+				m := multiplier(next)
+				if m == 0 {
+					continue
+				}
+				step := float32(1)
+				if dx != 0 && dy != 0 {
+					// Don't cut corners past tiles we can't cross.
+					if multiplier(ny*continentGrid+tx) == 0 || multiplier(ty*continentGrid+nx) == 0 {
+						continue
+					}
+					step = pathDiagonal
+				}
+				cost := p.cost[node.idx] + step/m
+				if p.seen[next] == p.search && p.cost[next] <= cost {
+					continue
+				}
+				p.touch(next, cost, node.idx)
+				p.push(pathNode{next, cost + heuristic(next)})
+			}
+		}
+	}
+	if !found {
+		return nil
+	}
+
+	// Walk back from the goal, then smooth out the zigzags.
+	var tiles []int32
+	for idx := goal; idx != -1; idx = p.parent[idx] {
+		tiles = append(tiles, idx)
+	}
+	for i, j := 0, len(tiles)-1; i < j; i, j = i+1, j-1 {
+		tiles[i], tiles[j] = tiles[j], tiles[i]
+	}
+	var path []Waypoint
+	for from := 0; from < len(tiles)-1; {
+		to := from + 1
+		for to+1 < len(tiles) && p.clear(tiles[from], tiles[to+1], multiplier) {
+			to++
+		}
+		if to < len(tiles)-1 {
+			path = append(path, tileCenter(tiles[to]))
+		}
+		from = to
+	}
+	return append(path, Waypoint{toX, toY})
+}
+
+// clear returns true if a straight walk from one tile to another only crosses terrain at least as quick as either end, so cutting straight across doesn't wander into anything worse.
+func (p *Pathfinder) clear(from, to int32, multiplier func(int32) float32) bool {
+	slowest := min(multiplier(from), multiplier(to))
+	if slowest == 0 {
+		slowest = math.SmallestNonzeroFloat32
+	}
+	x0, y0 := float64(from%continentGrid)+0.5, float64(from/continentGrid)+0.5
+	x1, y1 := float64(to%continentGrid)+0.5, float64(to/continentGrid)+0.5
+	steps := int(math.Ceil(math.Max(math.Abs(x1-x0), math.Abs(y1-y0)) * 2))
+	for i := 1; i < steps; i++ {
+		t := float64(i) / float64(steps)
+		idx := int32(y0+(y1-y0)*t)*continentGrid + int32(x0+(x1-x0)*t)
+		if multiplier(idx) < slowest {
+			return false
+		}
+	}
+	return true
+}
+
+// touch records the best known cost of getting to a tile this search.
+func (p *Pathfinder) touch(idx int32, cost float32, parent int32) {
+	p.seen[idx] = p.search
+	p.cost[idx] = cost
+	p.parent[idx] = parent
+}
+
+// push adds a node to the open list, which is a binary min-heap on f.
+func (p *Pathfinder) push(node pathNode) {
+	p.open = append(p.open, node)
+	i := len(p.open) - 1
+	for i > 0 {
+		parent := (i - 1) / 2
+		if p.open[parent].f <= p.open[i].f {
+			break
+		}
+		p.open[parent], p.open[i] = p.open[i], p.open[parent]
+		i = parent
+	}
+}
+
+// pop takes the node with the lowest f off the open list.
+func (p *Pathfinder) pop() pathNode {
+	top := p.open[0]
+	last := len(p.open) - 1
+	p.open[0] = p.open[last]
+	p.open = p.open[:last]
+	for i := 0; ; {
+		smallest := i
+		if l := 2*i + 1; l < last && p.open[l].f < p.open[smallest].f {
+			smallest = l
+		}
+		if r := 2*i + 2; r < last && p.open[r].f < p.open[smallest].f {
+			smallest = r
+		}
+		if smallest == i {
+			break
+		}
+		p.open[i], p.open[smallest] = p.open[smallest], p.open[i]
+		i = smallest
+	}
+	return top
+}
+
+// tileIndex returns the index of the tile at the given pixel coordinates across the whole continent.
+func tileIndex(x, y float64) (int32, bool) {
+	tx, ty, ok := TileCoords(x, y)
+	if !ok {
+		return 0, false
+	}
+	return int32(ty*continentGrid + tx), true
+}
+
+// tileCenter returns the pixel coordinates of the middle of the tile.
+func tileCenter(idx int32) Waypoint {
+	return Waypoint{
+		X: (float64(idx%continentGrid) + 0.5) * TileSize,
+		Y: (float64(idx/continentGrid) + 0.5) * TileSize,
+	}
+}
@@ -0,0 +1,94 @@
+package world
+
+import "testing"
+
+// paint sets every tile from x0, y0 up to but not including x1, y1 on the continent's tile grid to the given terrain.
+func paint(c *Continent, x0, y0, x1, y1 int, terrain Terrain) {
+	for ty := y0; ty < y1; ty++ {
+		for tx := x0; tx < x1; tx++ {
+			c.tileAtGrid(tx, ty).Terrain = terrain
+		}
+	}
+}
+
+// walled returns a continent with a grassy field split by a river, with a gap in it near the bottom.
+func walled() *Continent {
+	c := NewContinent(1)
+	c.ClearMobs()
+	paint(c, 10, 10, 70, 70, TerrainGrass)
+	paint(c, 40, 10, 41, 60, TerrainWater)
+	return c
+}
+
+// pixel returns the middle of the given tile.
+func pixel(tile int) float64 {
+	return (float64(tile) + 0.5) * TileSize
+}
+
+func TestPathAroundWater(t *testing.T) {
+	c := walled()
+	mob := c.NewMob(1, 1, pixel(20), pixel(30))
+	path := c.Path(mob, pixel(60), pixel(30))
+	if len(path) < 2 {
+		t.Fatalf("expected a detour, got %v", path)
+	}
+	if last := path[len(path)-1]; last.X != pixel(60) || last.Y != pixel(30) {
+		t.Errorf("path ends at %v, not the goal", last)
+	}
+	for _, waypoint := range path {
+		if c.TerrainAt(waypoint.X, waypoint.Y) == TerrainWater {
+			t.Errorf("waypoint %v is in the water", waypoint)
+		}
+	}
+	// The only way across is the gap below the river.
+	crossed := false
+	for _, waypoint := range path {
+		if waypoint.Y >= pixel(60)-TileSize {
+			crossed = true
+		}
+	}
+	if !crossed {
+		t.Errorf("path %v doesn't go through the gap", path)
+	}
+}
+
+func TestPathStraight(t *testing.T) {
+	c := walled()
+	mob := c.NewMob(1, 1, pixel(20), pixel(30))
+	if path := c.Path(mob, pixel(30), pixel(35)); path != nil {
+		t.Errorf("expected to walk straight, got %v", path)
+	}
+}
+
+func TestPathCaravanFords(t *testing.T) {
+	c := walled()
+	mob := c.NewMob(1, 1, pixel(20), pixel(30))
+	var caravan SchlubID
+	caravan.SetKindID(int(SchlubKindCaravanVagrant))
+	mob.AddSchlub(caravan)
+	for _, waypoint := range c.Path(mob, pixel(60), pixel(30)) {
+		if waypoint.Y >= pixel(60)-TileSize {
+			t.Fatalf("caravan went the long way round through %v", waypoint)
+		}
+	}
+}
+
+func BenchmarkPathDetour(b *testing.B) {
+	c := walled()
+	mob := c.NewMob(1, 1, pixel(20), pixel(30))
+	c.Path(mob, pixel(60), pixel(30)) // Build the pathfinder up front.
+	b.ResetTimer()
+	for range b.N {
+		c.Path(mob, pixel(60), pixel(30))
+	}
+}
+
+func BenchmarkPathWander(b *testing.B) {
+	c := walled()
+	mob := c.NewMob(1, 1, pixel(20), pixel(30))
+	c.Path(mob, pixel(22), pixel(31))
+	b.ResetTimer()
+	for range b.N {
+		c.Path(mob, pixel(22), pixel(31))
+	}
+}
@@ -10,6 +10,7 @@ type Player struct {
 	VisibleMobIDs []ID        // List of mobs visible to the player
 	Color         color.NRGBA // Player's color in NRGBA format
 	Count         int         // This is a client-side only field generated by MetaRefresh. It is used for highscore.
+	Spectator     bool        // Spectators watch the table without a mob of their own.
 }
 
 // NewPlayer makes a new player, wow.
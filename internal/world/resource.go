@@ -0,0 +1,81 @@
+package world
+
+import "math"
+
+// Resource is a patch of food lying around a fief for mobs to forage.
+type Resource struct {
+	ID   ID
+	X, Y float64
+	Food int
+}
+
+// Radius returns how far the resource spreads, which shrinks as it gets eaten.
+func (r *Resource) Radius() float64 {
+	return math.Max(4, float64(r.Food)/2)
+}
+
+// Deplete takes up to amount food from the resource and returns how much was actually taken.
+func (r *Resource) Deplete(amount int) int {
+	amount = min(amount, r.Food)
+	r.Food -= amount
+	return amount
+}
+
+// Resources is a slice of resources.
+type Resources []*Resource
+
+// FindByID searches for a resource by its ID.
+func (r *Resources) FindByID(id ID) *Resource {
+	for _, res := range *r {
+		if res.ID == id {
+			return res
+		}
+	}
+	return nil
+}
+
+// Add appends a resource.
+func (r *Resources) Add(res *Resource) {
+	*r = append(*r, res)
+}
+
+// Remove deletes a resource.
+func (r *Resources) Remove(res *Resource) {
+	for i, existing := range *r {
+		if existing == res {
+			*r = append((*r)[:i], (*r)[i+1:]...)
+			return
+		}
+	}
+}
+
+// FindTouching returns the resources the mob is standing on.
+func (r *Resources) FindTouching(mob *Mob) Resources {
+	var touching Resources
+	for _, res := range *r {
+		if CircleIntersectsCircle(mob.X, mob.Y, mob.Radius(), res.X, res.Y, res.Radius()) {
+			touching = append(touching, res)
+		}
+	}
+	return touching
+}
+
+// FindVisible returns the resources the mob can see.
+func (r *Resources) FindVisible(mob *Mob) Resources {
+	var visible Resources
+	for _, res := range *r {
+		if CircleIntersectsCircle(mob.X, mob.Y, mob.Vision(), res.X, res.Y, res.Radius()) {
+			visible = append(visible, res)
+		}
+	}
+	return visible
+}
+
+// Fertile returns true if food can grow on the terrain.
+func (t Terrain) Fertile() bool {
+	switch t {
+	case TerrainGrass, TerrainGrassyDirt, TerrainGrassySand, TerrainGrassyRocks, TerrainPines:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,60 @@
+package world
+
+import "testing"
+
+func TestResourceDeplete(t *testing.T) {
+	res := &Resource{Food: 30}
+	full := res.Radius()
+	if took := res.Deplete(12); took != 12 || res.Food != 18 {
+		t.Errorf("took %d, %d left", took, res.Food)
+	}
+	if res.Radius() >= full {
+		t.Errorf("eating didn't shrink the resource, %v vs %v", res.Radius(), full)
+	}
+	if took := res.Deplete(100); took != 18 || res.Food != 0 {
+		t.Errorf("took %d of the last 18, %d left", took, res.Food)
+	}
+	if took := res.Deplete(1); took != 0 {
+		t.Errorf("took %d from nothing", took)
+	}
+	if res.Radius() <= 0 {
+		t.Errorf("eaten up resource has no size to be found by")
+	}
+}
+
+func TestResources(t *testing.T) {
+	var resources Resources
+	near := &Resource{ID: 1, X: 100, Y: 100, Food: 10}
+	far := &Resource{ID: 2, X: 100 + 10000, Y: 100, Food: 10}
+	resources.Add(near)
+	resources.Add(far)
+	if resources.FindByID(1) != near || resources.FindByID(3) != nil {
+		t.Errorf("FindByID found the wrong resources")
+	}
+	mob := &Mob{X: 100 + near.Radius(), Y: 100}
+	if got := resources.FindTouching(mob); len(got) != 1 || got[0] != near {
+		t.Errorf("mob is touching %d resources, want the near one", len(got))
+	}
+	if got := resources.FindVisible(mob); len(got) != 1 || got[0] != near {
+		t.Errorf("mob can see %d resources, want the near one", len(got))
+	}
+	resources.Remove(near)
+	if len(resources) != 1 || resources[0] != far {
+		t.Errorf("removing left %d resources", len(resources))
+	}
+}
+
+func TestFertile(t *testing.T) {
+	for terrain, fertile := range map[Terrain]bool{
+		TerrainGrass:       true,
+		TerrainPines:       true,
+		TerrainWater:       false,
+		TerrainSand:        false,
+		TerrainRocks:       false,
+		TerrainGrassyRocks: true,
+	} {
+		if terrain.Fertile() != fertile {
+			t.Errorf("%s fertile = %v, want %v", terrain, terrain.Fertile(), fertile)
+		}
+	}
+}
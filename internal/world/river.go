@@ -0,0 +1,105 @@
+package world
+
+import (
+	"math"
+	"math/rand"
+)
+
+const (
+	RiverCount        = 16   // Rivers traced across each continent.
+	RiverFordEvery    = 24   // Every so many tiles a river runs shallow enough to walk across.
+	riverMinLength    = 16   // Rivers shorter than this aren't worth carving.
+	riverMaxLength    = 1500 // Rivers that wander this far without finding water are given up on.
+	riverAttempts     = 20   // Random tiles tried per river for somewhere high enough to start.
+	riverBankMoisture = 0.25 // How much wetter the land either side of a river is.
+	riverSalt         = 0x7269766572
+)
+
+// riverSteps are the ways a river can go from a tile. There's no going diagonally, so rivers are never leaky at the corners.
+var riverSteps = [4][2]int{{1, 0}, {-1, 0}, {0, 1}, {0, -1}}
+
+// carveRivers traces rivers downhill from the hills to the nearest water, turning the tiles they run through into water and dampening their banks.
+func (c *Continent) carveRivers() {
+	rng := rand.New(rand.NewSource(int64(nameHash(uint64(c.Sneed), riverSalt))))
+	touched := make(map[*Fief]bool)
+	for range RiverCount {
+		for range riverAttempts {
+			tx, ty := rng.Intn(continentGrid), rng.Intn(continentGrid)
+			tile := c.tileAtGrid(tx, ty)
+			if tile.Terrain == TerrainWater || tile.Elevation < HillLevel {
+				continue
+			}
+			if course, ok := c.traceRiver(tx, ty); ok && len(course) >= riverMinLength {
+				c.carveRiver(course, touched)
+				break
+			}
+		}
+	}
+	for fief := range touched {
+		fief.survey()
+	}
+}
+
+// traceRiver follows the land downhill from the given tile until it finds water, returning the tiles along the way. When there's no downhill left it carries on out of the lowest way it hasn't been, like a lake filling up until it spills over. It returns false if the river never finds water.
+func (c *Continent) traceRiver(tx, ty int) ([][2]int, bool) {
+	var course [][2]int
+	visited := make(map[[2]int]bool)
+	for len(course) < riverMaxLength {
+		course = append(course, [2]int{tx, ty})
+		visited[[2]int{tx, ty}] = true
+
+		next, lowest := [2]int{-1, -1}, math.Inf(1)
+		for _, step := range riverSteps {
+			nx, ny := tx+step[0], ty+step[1]
+			tile := c.tileAtGrid(nx, ny)
+			if tile == nil || visited[[2]int{nx, ny}] {
+				continue
+			}
+			if tile.Terrain == TerrainWater {
+				return course, true
+			}
+			if tile.Elevation < lowest {
+				next, lowest = [2]int{nx, ny}, tile.Elevation
+			}
+		}
+		if next[0] < 0 {
+			// Boxed in by our own course.
+			return nil, false
+		}
+		tx, ty = next[0], next[1]
+	}
+	return nil, false
+}
+
+// carveRiver turns the course into water, leaving fords every so often, and makes the land alongside wetter. Fiefs whose tiles changed are added to touched.
+func (c *Continent) carveRiver(course [][2]int, touched map[*Fief]bool) {
+	for i, at := range course {
+		for _, step := range riverSteps {
+			bx, by := at[0]+step[0], at[1]+step[1]
+			bank := c.tileAtGrid(bx, by)
+			if bank == nil || bank.Terrain == TerrainWater {
+				continue
+			}
+			bank.Moisture = min(bank.Moisture+riverBankMoisture, 1)
+			bank.Terrain = getTerrain(bank.Elevation, bank.Temperature, bank.Moisture)
+			touched[c.GetFiefAt(bx/FiefSize, by/FiefSize)] = true
+		}
+		if i%RiverFordEvery == RiverFordEvery-1 {
+			continue
+		}
+		c.tileAtGrid(at[0], at[1]).Terrain = TerrainWater
+		touched[c.GetFiefAt(at[0]/FiefSize, at[1]/FiefSize)] = true
+	}
+}
+
+// tileAtGrid returns the tile at the given tile grid coordinates across the whole continent, or nil if they're off it.
+func (c *Continent) tileAtGrid(tx, ty int) *Tile {
+	if tx < 0 || ty < 0 || tx >= continentGrid || ty >= continentGrid {
+		return nil
+	}
+	fief := c.GetFiefAt(tx/FiefSize, ty/FiefSize)
+	if fief == nil {
+		return nil
+	}
+	return &fief.Tiles[(ty%FiefSize)*FiefSize+tx%FiefSize]
+}
@@ -0,0 +1,114 @@
+package world
+
+import "math"
+
+const (
+	SettlementCost        = 10   // Schlubs it takes to found a settlement, which also makes up its health.
+	SettlementRadius      = 12.0 // How big a settlement is, for bumping into it.
+	SettlementReach       = 80.0 // How close a mob has to be for a settlement to send schlubs its way.
+	SettlementFood        = 5    // Food a settlement draws from its fief's resources to raise a schlub.
+	SettlementRounds      = 10   // Rounds between a settlement raising schlubs.
+	SettlementMaxFailures = 5    // Times in a row a settlement can go hungry before it's abandoned.
+	SettlementDefense     = RankIron
+)
+
+// Settlement is a village founded in a fief that raises schlubs for its owner's mobs while the fief has food to spare.
+type Settlement struct {
+	ID       ID
+	OwnerID  ID
+	Fief     int // Index of the fief the settlement was founded in.
+	X, Y     float64
+	Health   int // Damage the settlement can take before it's torn down.
+	Failures int // Times in a row the settlement went hungry.
+	Rounds   int // Rounds since the settlement last raised a schlub.
+}
+
+// Struggling returns true if the settlement has been going hungry.
+func (s *Settlement) Struggling() bool {
+	return s.Failures > 1
+}
+
+// Abandoned returns true if the settlement has gone hungry for too long to keep going.
+func (s *Settlement) Abandoned() bool {
+	return s.Failures > SettlementMaxFailures
+}
+
+// Touches returns true if the mob is bumping into the settlement.
+func (s *Settlement) Touches(mob *Mob) bool {
+	return CircleIntersectsCircle(mob.X, mob.Y, mob.Radius(), s.X, s.Y, SettlementRadius)
+}
+
+// Reaches returns true if the mob is close enough to get schlubs from the settlement.
+func (s *Settlement) Reaches(mob *Mob) bool {
+	return CircleIntersectsCircle(mob.X, mob.Y, mob.Radius(), s.X, s.Y, SettlementReach)
+}
+
+// Distance returns how far the mob is from the settlement.
+func (s *Settlement) Distance(mob *Mob) float64 {
+	return math.Hypot(mob.X-s.X, mob.Y-s.Y)
+}
+
+// SiegeDamage returns how much damage the mob does to a settlement it's besieging. Bigger mobs do more, and strength scales that up or down.
+func (m *Mob) SiegeDamage() int {
+	return ClashCount(1+len(m.Schlubs)/10, m.stats().Strength, SettlementDefense)
+}
+
+// Settlements is a slice of settlements.
+type Settlements []*Settlement
+
+// FindByID searches for a settlement by its ID.
+func (s *Settlements) FindByID(id ID) *Settlement {
+	for _, settlement := range *s {
+		if settlement.ID == id {
+			return settlement
+		}
+	}
+	return nil
+}
+
+// FindByFief returns the settlement in the given fief, if there is one.
+func (s *Settlements) FindByFief(fief int) *Settlement {
+	for _, settlement := range *s {
+		if settlement.Fief == fief {
+			return settlement
+		}
+	}
+	return nil
+}
+
+// FindByOwner returns the settlements owned by the given owner.
+func (s *Settlements) FindByOwner(owner ID) Settlements {
+	var owned Settlements
+	for _, settlement := range *s {
+		if settlement.OwnerID == owner {
+			owned = append(owned, settlement)
+		}
+	}
+	return owned
+}
+
+// Add appends a settlement.
+func (s *Settlements) Add(settlement *Settlement) {
+	*s = append(*s, settlement)
+}
+
+// Remove deletes a settlement.
+func (s *Settlements) Remove(settlement *Settlement) {
+	for i, existing := range *s {
+		if existing == settlement {
+			*s = append((*s)[:i], (*s)[i+1:]...)
+			return
+		}
+	}
+}
+
+// FindVisible returns the settlements the mob can see.
+func (s *Settlements) FindVisible(mob *Mob) Settlements {
+	var visible Settlements
+	for _, settlement := range *s {
+		if CircleIntersectsCircle(mob.X, mob.Y, mob.Vision(), settlement.X, settlement.Y, SettlementRadius) {
+			visible = append(visible, settlement)
+		}
+	}
+	return visible
+}
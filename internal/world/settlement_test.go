@@ -0,0 +1,76 @@
+package world
+
+import "testing"
+
+func TestSettlementHunger(t *testing.T) {
+	var settlement Settlement
+	for failures := 0; failures <= SettlementMaxFailures+1; failures++ {
+		settlement.Failures = failures
+		if settlement.Struggling() != (failures > 1) {
+			t.Errorf("struggling after %d failures = %v", failures, settlement.Struggling())
+		}
+		if settlement.Abandoned() != (failures == SettlementMaxFailures+1) {
+			t.Errorf("abandoned after %d failures = %v", failures, settlement.Abandoned())
+		}
+	}
+}
+
+func TestSettlementReach(t *testing.T) {
+	settlement := &Settlement{X: 100, Y: 100}
+	mob := &Mob{X: 100, Y: 100}
+	if !settlement.Touches(mob) || !settlement.Reaches(mob) || settlement.Distance(mob) != 0 {
+		t.Errorf("mob on top of the settlement isn't touching it")
+	}
+	mob.X = 100 + SettlementRadius + mob.Radius() + 1
+	if settlement.Touches(mob) || !settlement.Reaches(mob) {
+		t.Errorf("mob nearby is touching it or out of reach")
+	}
+	mob.X = 100 + SettlementReach + mob.Radius() + 1
+	if settlement.Reaches(mob) {
+		t.Errorf("mob far away is in reach")
+	}
+	if settlement.Distance(mob) != mob.X-100 {
+		t.Errorf("mob is %v away, want %v", settlement.Distance(mob), mob.X-100)
+	}
+}
+
+func TestSiegeDamage(t *testing.T) {
+	few := mobOf(SchlubKindVagrant, 5)
+	many := mobOf(SchlubKindVagrant, 50)
+	warriors := mobOf(SchlubKindWarrior, 50)
+	if few.SiegeDamage() < 1 {
+		t.Errorf("a few vagrants do no damage")
+	}
+	if many.SiegeDamage() <= few.SiegeDamage() {
+		t.Errorf("many vagrants do %d, a few do %d", many.SiegeDamage(), few.SiegeDamage())
+	}
+	if warriors.SiegeDamage() <= many.SiegeDamage() {
+		t.Errorf("warriors do %d, vagrants do %d", warriors.SiegeDamage(), many.SiegeDamage())
+	}
+}
+
+func TestSettlements(t *testing.T) {
+	var settlements Settlements
+	a := &Settlement{ID: 1, OwnerID: 1, Fief: 3, X: 100, Y: 100}
+	b := &Settlement{ID: 2, OwnerID: 2, Fief: 4, X: 10000, Y: 100}
+	c := &Settlement{ID: 3, OwnerID: 1, Fief: 5, X: 20000, Y: 100}
+	settlements.Add(a)
+	settlements.Add(b)
+	settlements.Add(c)
+	if settlements.FindByID(2) != b || settlements.FindByID(4) != nil {
+		t.Errorf("FindByID found the wrong settlements")
+	}
+	if settlements.FindByFief(5) != c || settlements.FindByFief(6) != nil {
+		t.Errorf("FindByFief found the wrong settlements")
+	}
+	if owned := settlements.FindByOwner(1); len(owned) != 2 || owned[0] != a || owned[1] != c {
+		t.Errorf("player 1 owns %d settlements, want 2", len(owned))
+	}
+	if visible := settlements.FindVisible(&Mob{X: 100, Y: 100}); len(visible) != 1 || visible[0] != a {
+		t.Errorf("mob can see %d settlements, want 1", len(visible))
+	}
+	settlements.Remove(a)
+	if len(settlements) != 2 || settlements.FindByID(1) != nil {
+		t.Errorf("removing left %d settlements", len(settlements))
+	}
+}
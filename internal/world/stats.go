@@ -1,5 +1,7 @@
 package world
 
+import "math"
+
 type Rank int
 
 const (
@@ -84,3 +86,62 @@ func (s *Stats) Apply(other *Stats) *Stats {
 	return new.clamp()
 
 }
+
+// kindStats are what each kind of schlub brings to its mob's stats.
+var kindStats = map[SchlubID]Stats{
+	SchlubKindPlayer:         {Strength: RankIron, Agility: RankIron, Charisma: RankGold, Endurance: RankIron, Luck: RankIron},
+	SchlubKindVagrant:        {Strength: RankBronze, Agility: RankIron, Charisma: RankBronze, Endurance: RankBronze, Luck: RankIron},
+	SchlubKindMonk:           {Strength: RankWooden, Agility: RankBronze, Charisma: RankSteel, Endurance: RankIron, Luck: RankBronze},
+	SchlubKindWarrior:        {Strength: RankSteel, Agility: RankBronze, Charisma: RankWooden, Endurance: RankSteel, Luck: RankBronze},
+	SchlubKindCaravanVagrant: {Strength: RankWooden, Agility: RankWooden, Charisma: RankWooden, Endurance: RankSteel, Luck: RankIron},
+	SchlubKindCaravanMonk:    {Strength: RankWooden, Agility: RankWooden, Charisma: RankWooden, Endurance: RankSteel, Luck: RankIron},
+	SchlubKindCaravanWarrior: {Strength: RankWooden, Agility: RankWooden, Charisma: RankWooden, Endurance: RankSteel, Luck: RankIron},
+}
+
+// StatsFor averages the stats of every schlub's kind, age and item, rounding to the nearest rank, then applies the modifiers on top.
+func StatsFor(schlubs []SchlubID, modifiers ...Modifier) *Stats {
+	var sum [5]int
+	for _, schlub := range schlubs {
+		stats := kindStats[SchlubID(schlub.KindID())]
+		// The young haven't grown into their strength yet and the old have outgrown theirs, though they've picked up a way with words.
+		if schlub.Young() {
+			stats.Strength--
+			stats.Endurance--
+		} else if schlub.Elder() {
+			stats.Strength--
+			stats.Agility--
+			stats.Charisma++
+		}
+		// Whatever they're carrying helps too.
+		item := schlub.Item().Info().Stats
+		stats = *stats.Apply(&item)
+		sum[0] += int(stats.Strength)
+		sum[1] += int(stats.Agility)
+		sum[2] += int(stats.Charisma)
+		sum[3] += int(stats.Endurance)
+		sum[4] += int(stats.Luck)
+	}
+	avg := func(total int) Rank {
+		if len(schlubs) == 0 {
+			return RankNone
+		}
+		return Rank((total + len(schlubs)/2) / len(schlubs))
+	}
+	stats := &Stats{
+		Strength:  avg(sum[0]),
+		Agility:   avg(sum[1]),
+		Charisma:  avg(sum[2]),
+		Endurance: avg(sum[3]),
+		Luck:      avg(sum[4]),
+	}
+	for _, modifier := range modifiers {
+		stats = stats.Apply(&modifier.Stats)
+	}
+	return stats
+}
+
+// ClashCount returns how many schlubs a clash with the given base count takes, scaled by a quarter for every rank the attack has over the defense. A clash always takes at least one.
+func ClashCount(base int, attack, defense Rank) int {
+	count := int(math.Round(float64(base) * (1 + 0.25*float64(attack-defense))))
+	return max(1, count)
+}
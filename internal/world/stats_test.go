@@ -0,0 +1,127 @@
+package world
+
+import "testing"
+
+// schlub makes a schlub of the given kind, age and item.
+func schlub(kind SchlubID, age int, item Item) SchlubID {
+	var s SchlubID
+	s.SetKindID(int(kind))
+	s.SetAgeID(age)
+	s.SetItemID(int(item))
+	return s
+}
+
+const adult = AgeYoung + 1
+
+func TestStatsFor(t *testing.T) {
+	tests := []struct {
+		name      string
+		schlubs   []SchlubID
+		modifiers []Modifier
+		want      Stats
+	}{
+		{
+			name: "nobody",
+			want: Stats{},
+		},
+		{
+			name:    "warrior",
+			schlubs: []SchlubID{schlub(SchlubKindWarrior, adult, ItemNone)},
+			want:    Stats{Strength: RankSteel, Agility: RankBronze, Charisma: RankWooden, Endurance: RankSteel, Luck: RankBronze},
+		},
+		{
+			name:    "young warrior",
+			schlubs: []SchlubID{schlub(SchlubKindWarrior, 0, ItemNone)},
+			want:    Stats{Strength: RankIron, Agility: RankBronze, Charisma: RankWooden, Endurance: RankIron, Luck: RankBronze},
+		},
+		{
+			name:    "elder monk",
+			schlubs: []SchlubID{schlub(SchlubKindMonk, AgeElder, ItemNone)},
+			want:    Stats{Strength: RankNone, Agility: RankWooden, Charisma: RankGold, Endurance: RankIron, Luck: RankBronze},
+		},
+		{
+			name:    "player never ages",
+			schlubs: []SchlubID{schlub(SchlubKindPlayer, 0, ItemNone)},
+			want:    Stats{Strength: RankIron, Agility: RankIron, Charisma: RankGold, Endurance: RankIron, Luck: RankIron},
+		},
+		{
+			name:    "vagrant with a banner",
+			schlubs: []SchlubID{schlub(SchlubKindVagrant, adult, ItemBanner)},
+			want:    Stats{Strength: RankBronze, Agility: RankIron, Charisma: RankSteel, Endurance: RankBronze, Luck: RankIron},
+		},
+		{
+			name: "warrior and monk round to nearest",
+			schlubs: []SchlubID{
+				schlub(SchlubKindWarrior, adult, ItemNone),
+				schlub(SchlubKindMonk, adult, ItemNone),
+			},
+			want: Stats{Strength: RankIron, Agility: RankBronze, Charisma: RankIron, Endurance: RankSteel, Luck: RankBronze},
+		},
+		{
+			name:      "marshy fief",
+			schlubs:   []SchlubID{schlub(SchlubKindVagrant, adult, ItemNone)},
+			modifiers: []Modifier{{Stats: Stats{Agility: -1}, Reason: "Marshy"}},
+			want:      Stats{Strength: RankBronze, Agility: RankBronze, Charisma: RankBronze, Endurance: RankBronze, Luck: RankIron},
+		},
+		{
+			name:    "modifiers stack and clamp",
+			schlubs: []SchlubID{schlub(SchlubKindVagrant, adult, ItemNone)},
+			modifiers: []Modifier{
+				{Stats: Stats{Luck: 3}},
+				{Stats: Stats{Luck: 3, Strength: -5}},
+			},
+			want: Stats{Strength: RankNone, Agility: RankIron, Charisma: RankBronze, Endurance: RankBronze, Luck: RankDiamond},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StatsFor(tt.schlubs, tt.modifiers...); *got != tt.want {
+				t.Errorf("StatsFor() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClashCount(t *testing.T) {
+	tests := []struct {
+		base            int
+		attack, defense Rank
+		want            int
+	}{
+		{4, RankIron, RankIron, 4},
+		{4, RankSteel, RankIron, 5},
+		{4, RankIron, RankSteel, 3},
+		{10, RankGold, RankBronze, 18},
+		{10, RankBronze, RankGold, 3},
+		{4, RankWooden, RankDiamond, 1},
+		{0, RankDiamond, RankNone, 1},
+		{1, RankNone, RankNone, 1},
+	}
+	for _, tt := range tests {
+		if got := ClashCount(tt.base, tt.attack, tt.defense); got != tt.want {
+			t.Errorf("ClashCount(%d, %s, %s) = %d, want %d", tt.base, tt.attack, tt.defense, got, tt.want)
+		}
+	}
+}
+
+func TestStatsDriveMobs(t *testing.T) {
+	warriors := &Mob{Schlubs: []SchlubID{schlub(SchlubKindWarrior, adult, ItemNone), schlub(SchlubKindWarrior, adult, ItemNone)}}
+	monks := &Mob{Schlubs: []SchlubID{schlub(SchlubKindMonk, adult, ItemNone), schlub(SchlubKindMonk, adult, ItemNone)}}
+	if got := warriors.Damage(monks, 4); got != 5 {
+		t.Errorf("warriors slay %d monks, want 5", got)
+	}
+	if got := monks.Damage(warriors, 4); got != 1 {
+		t.Errorf("monks slay %d warriors, want 1", got)
+	}
+	if got := monks.Conversions(warriors, 4); got != 7 {
+		t.Errorf("monks convert %d warriors, want 7", got)
+	}
+
+	vagrants := &Mob{Schlubs: []SchlubID{schlub(SchlubKindVagrant, adult, ItemNone)}}
+	fast := vagrants.Speed()
+	marsh := &Fief{modifiers: []Modifier{{Stats: Stats{Agility: -1}}}}
+	vagrants.RefreshStats(marsh)
+	if slow := vagrants.Speed(); slow >= fast {
+		t.Errorf("marsh didn't slow vagrants down, %v vs %v", slow, fast)
+	}
+}
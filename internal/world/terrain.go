@@ -6,6 +6,20 @@ import (
 
 type Terrain int
 
+const (
+	SeaLevel   = 0.19 // Land lower than this is under water.
+	CoastLevel = 0.22 // Land between the sea and this is beach.
+	HillLevel  = 0.4  // Land higher than this is hills.
+	PeakLevel  = 0.53 // Land higher than this is highlands.
+
+	elevationOctaves  = 5     // Layers of ever finer noise that make up the lay of the land.
+	elevationScale    = 0.15  // Frequency of the broadest layer, so the land rises and falls over several fiefs.
+	climateScale      = 0.4   // Frequency of the temperature and moisture noise.
+	coastWidth        = 0.04  // How far in from each edge of the continent the land starts sinking into the sea, as a fraction of its span.
+	temperatureOffset = 7919  // Temperature's noise is sampled this far off elevation's so the two have nothing to do with each other.
+	moistureOffset    = -6101 // Same again for moisture.
+)
+
 const (
 	TerrainNone Terrain = iota
 	TerrainDirt
@@ -23,13 +37,6 @@ const (
 	TerrainCount // Total number of terrain types
 )
 
-func NewTerrain(fate *Fate, x, y float64) Terrain {
-	elevation := getElevation(fate, x, y)
-	temperature := getTemperature(fate, x, y, elevation)
-	moisture := getMoisture(fate, x, y)
-	return getTerrain(elevation, temperature, moisture)
-}
-
 func (t Terrain) ImageName() string {
 	switch t {
 	case TerrainNone:
@@ -56,6 +63,8 @@ func (t Terrain) ImageName() string {
 		return "rocks"
 	case TerrainWater:
 		return "water"
+	case TerrainPines:
+		return "pines"
 	default:
 		return "dirt"
 	}
@@ -97,27 +106,37 @@ func (t Terrain) String() string {
 func getElevation(fate *Fate, x, y float64) float64 {
 	var value float64
 	amplitude := 1.0
-	frequency := 1.0
+	frequency := elevationScale
 	maxValue := 0.0
 
-	value += fate.Determine(
-		x,
-		y*frequency,
-	) * amplitude
+	for range elevationOctaves {
+		value += fate.Determine(
+			x*frequency,
+			y*frequency,
+		) * amplitude
 
-	maxValue += amplitude
-	amplitude *= 0.5
-	frequency *= 2.0
+		maxValue += amplitude
+		amplitude *= 0.5
+		frequency *= 2.0
+	}
 
 	value = (value/maxValue + 1) / 2
 	value = math.Pow(value, 1.5)
-	return value
+	return value * getCoastline(x, y)
+}
+
+// getCoastline returns how much of the land's height is kept at the given position, sinking it into the sea towards the edges of the continent.
+func getCoastline(x, y float64) float64 {
+	edge := min(x, y, ContinentPixelSpan-x, ContinentPixelSpan-y) / (ContinentPixelSpan * coastWidth)
+	edge = clamp64(edge, 0, 1)
+	// Smoothstep, so the land eases into the sea rather than falling off a cliff.
+	return edge * edge * (3 - 2*edge)
 }
 
 func getTemperature(fate *Fate, x, y, elevation float64) float64 {
 	temp := (fate.Determine(
-		x,
-		y,
+		x*climateScale+temperatureOffset,
+		y*climateScale+temperatureOffset,
 	) + 1) / 2
 
 	return temp * (1 - elevation)
@@ -125,43 +144,48 @@ func getTemperature(fate *Fate, x, y, elevation float64) float64 {
 
 func getMoisture(fate *Fate, x, y float64) float64 {
 	moisture := (fate.Determine(
-		x,
-		y,
+		x*climateScale+moistureOffset,
+		y*climateScale+moistureOffset,
 	) + 1) / 2
 	return moisture
 }
 
 func getTerrain(elevation, temperature, moisture float64) Terrain {
-	if elevation < 0.3 {
-		if moisture < 0.2 {
+	if elevation < SeaLevel {
+		return TerrainWater
+	} else if elevation < CoastLevel {
+		// Beaches.
+		if temperature < 0.2 {
+			return TerrainRockySand
+		} else if moisture < 0.5 {
 			return TerrainSand
-		} else if moisture < 0.6 {
-			return TerrainGrassySand
-		} else if moisture <= 1.0 {
-			return TerrainWater
 		}
-	} else if elevation < 0.6 {
-		if moisture < 0.2 {
-			return TerrainRocks
-		} else if moisture < 0.3 {
-			return TerrainRockyDirt
-		} else if moisture < 0.4 {
+		return TerrainGrassySand
+	} else if elevation < HillLevel {
+		// Lowlands.
+		if moisture < 0.35 {
+			if temperature > 0.3 {
+				return TerrainSandyDirt
+			}
 			return TerrainDirt
-		} else if moisture < 0.6 {
-			return TerrainGrass
-		} else if moisture <= 1.0 {
+		} else if moisture < 0.5 {
 			return TerrainGrassyDirt
-		}
-	} else if elevation < 1.0 {
-		if moisture < 0.2 {
+		} else if moisture < 0.65 || temperature > 0.35 {
 			return TerrainGrass
+		}
+		return TerrainPines
+	} else if elevation < PeakLevel {
+		// Hills.
+		if moisture < 0.4 {
+			return TerrainRockyDirt
 		} else if moisture < 0.6 {
 			return TerrainGrassyRocks
-		} else if moisture < 0.8 {
-			return TerrainRockyDirt
-		} else if moisture <= 1.0 {
-			return TerrainRocks
 		}
+		return TerrainPines
+	}
+	// Highlands.
+	if moisture > 0.6 && temperature > 0.15 {
+		return TerrainPines
 	}
-	return TerrainGrass
+	return TerrainRocks
 }
@@ -0,0 +1,61 @@
+package world
+
+import (
+	"fmt"
+	"hash/fnv"
+	"testing"
+)
+
+// terrainHash hashes the terrain of every tile on the continent, row by row.
+func terrainHash(c *Continent) string {
+	h := fnv.New64a()
+	for ty := range continentGrid {
+		for tx := range continentGrid {
+			h.Write([]byte{byte(c.tileAtGrid(tx, ty).Terrain)})
+		}
+	}
+	return fmt.Sprintf("%016x", h.Sum64())
+}
+
+// The terrain these seeds make. If generation changes on purpose, update these with whatever the test says they are now.
+var goldenTerrain = map[uint]string{
+	1:  "a8c74b37cca4893e",
+	42: "f3741244645c9a44",
+}
+
+func TestTerrainGolden(t *testing.T) {
+	for seed, want := range goldenTerrain {
+		if got := terrainHash(NewContinent(seed)); got != want {
+			t.Errorf("seed %d terrain hashes to %s, want %s", seed, got, want)
+		}
+	}
+}
+
+func TestTerrainEverything(t *testing.T) {
+	var counts [TerrainCount]int
+	c := NewContinent(1)
+	for ty := range continentGrid {
+		for tx := range continentGrid {
+			counts[c.tileAtGrid(tx, ty).Terrain]++
+		}
+	}
+	for terrain := TerrainNone + 1; terrain < TerrainCount; terrain++ {
+		if counts[terrain] == 0 {
+			t.Errorf("no %s anywhere", terrain)
+		}
+	}
+	if counts[TerrainNone] != 0 {
+		t.Errorf("%d tiles have no terrain", counts[TerrainNone])
+	}
+}
+
+func TestTerrainCoast(t *testing.T) {
+	c := NewContinent(1)
+	for i := range continentGrid {
+		for _, at := range [][2]int{{i, 0}, {0, i}, {i, continentGrid - 1}, {continentGrid - 1, i}} {
+			if terrain := c.tileAtGrid(at[0], at[1]).Terrain; terrain != TerrainWater {
+				t.Fatalf("tile %d, %d on the edge is %s, not sea", at[0], at[1], terrain)
+			}
+		}
+	}
+}
@@ -3,11 +3,20 @@ package world
 const TileSize = 16 // Size of each tile in pixels
 
 type Tile struct {
-	Terrain Terrain
+	Terrain     Terrain
+	Elevation   float64 // How high up the tile is, from 0 to 1.
+	Temperature float64 // How warm the tile is, from 0 to 1.
+	Moisture    float64 // How wet the tile is, from 0 to 1.
 }
 
 func NewTile(fate *Fate, x, y float64) Tile {
+	elevation := getElevation(fate, x, y)
+	temperature := getTemperature(fate, x, y, elevation)
+	moisture := getMoisture(fate, x, y)
 	return Tile{
-		Terrain: NewTerrain(fate, x, y),
+		Terrain:     getTerrain(elevation, temperature, moisture),
+		Elevation:   elevation,
+		Temperature: temperature,
+		Moisture:    moisture,
 	}
 }